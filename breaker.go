@@ -3,7 +3,6 @@ package breaker
 import (
 	"context"
 	"os"
-	"os/signal"
 	"sync"
 	"time"
 )
@@ -21,6 +20,8 @@ func New() Interface {
 }
 
 // BreakByChannel returns a new breaker based on the channel.
+// It doesn't start a goroutine to wait for the channel
+// until the Done channel of the breaker is requested.
 //
 //  signal := make(chan struct{})
 //  go func() {
@@ -34,7 +35,7 @@ func New() Interface {
 //  background.Job().Do(interrupter)
 //
 func BreakByChannel(signal <-chan struct{}) Interface {
-	return (&channelBreaker{newBreaker(), make(chan struct{}), signal}).trigger()
+	return newChannelBreaker(signal).trigger()
 }
 
 // BreakByContext returns a new breaker based on the Context.
@@ -45,7 +46,7 @@ func BreakByChannel(signal <-chan struct{}) Interface {
 //  background.Job().Do(interrupter)
 //
 func BreakByContext(ctx context.Context, cancel context.CancelFunc) Interface {
-	return (&contextBreaker{ctx, cancel}).trigger()
+	return newContextBreaker(ctx, func(error) { cancel() }).trigger()
 }
 
 // BreakByDeadline closes the Done channel when the deadline occurs.
@@ -56,11 +57,7 @@ func BreakByContext(ctx context.Context, cancel context.CancelFunc) Interface {
 //  background.Job().Do(interrupter)
 //
 func BreakByDeadline(deadline time.Time) Interface {
-	timeout := time.Until(deadline)
-	if timeout < 0 {
-		return closedBreaker()
-	}
-	return newTimeoutBreaker(timeout).trigger()
+	return WithClock(systemClock).BreakByDeadline(deadline)
 }
 
 // BreakBySignal closes the Done channel when the breaker will receive OS signals.
@@ -72,7 +69,7 @@ func BreakByDeadline(deadline time.Time) Interface {
 //
 func BreakBySignal(sig ...os.Signal) Interface {
 	if len(sig) == 0 {
-		return closedBreaker()
+		return closedBreaker(Interrupted)
 	}
 	return newSignalBreaker(sig).trigger()
 }
@@ -85,13 +82,16 @@ func BreakBySignal(sig ...os.Signal) Interface {
 //  background.Job().Do(interrupter)
 //
 func BreakByTimeout(timeout time.Duration) Interface {
-	if timeout < 0 {
-		return closedBreaker()
-	}
-	return newTimeoutBreaker(timeout).trigger()
+	return WithClock(systemClock).BreakByTimeout(timeout)
 }
 
 // ToContext converts the breaker into the Context.
+// The Context shares the Done channel with the breaker,
+// so the conversion doesn't start any goroutine, it costs
+// only one small allocation of the adapter.
+// Its Deadline is the earliest deadline of time-based breakers.
+// The Context of the breaker returned by the BreakByContext
+// is returned as is.
 //
 //  interrupter := breaker.Multiplex(
 //  	breaker.BreakBySignal(os.Interrupt),
@@ -107,32 +107,198 @@ func BreakByTimeout(timeout time.Duration) Interface {
 //  handle(response)
 //
 func ToContext(br Interface) context.Context {
-	ctx, cancel := context.WithCancel(context.Background())
+	if br, is := br.(*contextBreaker); is {
+		return br.Context
+	}
+	return breakerContext{br}
+}
+
+// Cause returns the reason why the breaker was interrupted
+// or nil if the Done channel is not closed yet.
+// The reason is one of Closed, ChannelClosed, *ContextError,
+// *DeadlineError, *SignalError or *TimeoutError,
+// and all of them satisfy errors.Is(err, Interrupted).
+//
+//  interrupter := breaker.Multiplex(
+//  	breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM),
+//  	breaker.BreakByTimeout(time.Minute),
+//  )
+//  defer interrupter.Close()
+//
+//  <-interrupter.Done()
+//  var sig *breaker.SignalError
+//  if errors.As(breaker.Cause(interrupter), &sig) {
+//  	log.Println("interrupted by", sig.Signal)
+//  }
+//
+func Cause(br Interface) error {
+	if br, is := br.(interface{ cause() error }); is {
+		return br.cause()
+	}
+	return br.Err()
+}
+
+// CloseWithCause closes the breaker like the Close call does,
+// but the Cause call will return the passed cause instead of Closed.
+// Downstream consumers can distinguish a failure that aborted the work
+// from a regular close.
+//
+//  interrupter := breaker.BreakByTimeout(time.Minute)
+//  go background.Job().Do(interrupter)
+//
+//  if err := db.Ping(); err != nil {
+//  	breaker.CloseWithCause(interrupter, err)
+//  }
+//
+func CloseWithCause(br Interface, cause error) {
+	if br, is := br.(interface{ CloseWithCause(error) }); is {
+		br.CloseWithCause(cause)
+		return
+	}
+	br.Close()
+}
+
+// NoDeadline is returned by the Remaining call for breakers without a deadline.
+const NoDeadline time.Duration = 1<<63 - 1
+
+// Remaining returns the time left before the breaker will be interrupted.
+// It returns zero if the breaker is already interrupted and NoDeadline
+// if the breaker has no deadline. For multiplexed breakers, it returns
+// the time left until the quorum of them is interrupted by deadlines.
+//
+//  interrupter := breaker.BreakByTimeout(time.Minute)
+//  defer interrupter.Close()
+//
+//  for breaker.Remaining(interrupter) > batchDuration {
+//  	background.Batch().Do(interrupter)
+//  }
+//
+func Remaining(br Interface) time.Duration {
+	if br.Err() != nil {
+		return 0
+	}
+	if br, is := br.(interface{ remaining() time.Duration }); is {
+		return br.remaining()
+	}
+	at, ok := deadline(br)
+	if !ok {
+		return NoDeadline
+	}
+	now := time.Now()
+	if br, is := br.(interface{ now() time.Time }); is {
+		now = br.now()
+	}
+	if left := at.Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// Elapsed returns the time passed since the breaker was created.
+// It returns zero if the breaker doesn't track its creation time.
+//
+//  interrupter := breaker.BreakByTimeout(time.Minute)
+//  defer interrupter.Close()
+//
+//  background.Job().Do(interrupter)
+//  log.Println("job took", breaker.Elapsed(interrupter))
+//
+func Elapsed(br Interface) time.Duration {
+	if br, is := br.(interface{ elapsed() time.Duration }); is {
+		return br.elapsed()
+	}
+	return 0
+}
+
+// afterFunc calls the callback after the Done channel of the breaker is closed
+// and returns the function to unregister it. It uses the callbacks
+// of the package breakers and falls back to a goroutine for others.
+func afterFunc(br Interface, fn func()) (stop func()) {
+	if br, is := br.(interface{ afterFunc(func()) func() }); is {
+		return br.afterFunc(fn)
+	}
+	if br.Done() == nil {
+		return func() {}
+	}
+	var once sync.Once
+	done := make(chan struct{})
 	go func() {
-		<-br.Done()
-		cancel()
+		select {
+		case <-br.Done():
+			fn()
+		case <-done:
+		}
 	}()
-	return ctx
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// deadline returns the time when the breaker will be interrupted
+// if it is known.
+func deadline(br Interface) (time.Time, bool) {
+	if br, is := br.(interface{ Deadline() (time.Time, bool) }); is {
+		return br.Deadline()
+	}
+	return time.Time{}, false
 }
 
-func closedBreaker() Interface {
+func closedBy(cause error) error {
+	if cause == nil {
+		return Closed
+	}
+	return cause
+}
+
+func closedBreaker(cause error) Interface {
 	br := newBreaker()
-	br.Close()
+	br.release(cause)
 	return br
 }
 
 func newBreaker() *breaker {
-	return &breaker{signal: make(chan struct{})}
+	return newClockBreaker(systemClock)
+}
+
+func newClockBreaker(clock Clock) *breaker {
+	return &breaker{signal: make(chan struct{}), clock: clock, started: clock.Now()}
 }
 
 type breaker struct {
-	closer sync.Once
-	signal chan struct{}
+	closer  sync.Once
+	signal  chan struct{}
+	reason  error
+	clock   Clock
+	started time.Time
+	mu      sync.Mutex
+	hooks   *hook
+}
+
+// hook is an element of the doubly linked list of callbacks,
+// which allows unregistering them in constant time.
+type hook struct {
+	callback
+	prev, next *hook
+}
+
+// callback is called by the breaker after its Done channel is closed.
+type callback interface {
+	call()
+}
+
+type callbackFunc func()
+
+func (fn callbackFunc) call() {
+	fn()
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *breaker) Close() {
-	br.closer.Do(func() { close(br.signal) })
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *breaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
 }
 
 // Done returns a channel that's closed when a cancellation signal occurred.
@@ -158,42 +324,244 @@ func (br *breaker) IsReleased() bool {
 	return br.Err() != nil
 }
 
+// afterFunc registers the callback to call after the Done channel is closed
+// and returns the function to unregister it. If the Done channel is already
+// closed, the callback is called immediately.
+func (br *breaker) afterFunc(fn func()) (stop func()) {
+	h := &hook{callback: callbackFunc(fn)}
+	if !br.attach(h) {
+		fn()
+		return func() {}
+	}
+	return func() { br.detach(h) }
+}
+
+// attach puts the hook into the list of callbacks. It returns false
+// and doesn't keep the hook if the Done channel is already closed.
+func (br *breaker) attach(h *hook) bool {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	select {
+	case <-br.signal:
+		return false
+	default:
+	}
+	h.next = br.hooks
+	if br.hooks != nil {
+		br.hooks.prev = h
+	}
+	br.hooks = h
+	return true
+}
+
+// detach removes the hook from the list of callbacks.
+func (br *breaker) detach(h *hook) {
+	br.mu.Lock()
+	br.unhook(h)
+	br.mu.Unlock()
+}
+
+// unhook removes the callback from the list if it's still there.
+// The list is detached by the broadcast, so it does nothing
+// after the Done channel is closed.
+func (br *breaker) unhook(h *hook) {
+	select {
+	case <-br.signal:
+		return
+	default:
+	}
+	switch {
+	case h.prev != nil:
+		h.prev.next = h.next
+	case br.hooks == h:
+		br.hooks = h.next
+	default:
+		return
+	}
+	if h.next != nil {
+		h.next.prev = h.prev
+	}
+	h.prev, h.next = nil, nil
+}
+
+// broadcast closes the Done channel and calls the registered callbacks.
+func (br *breaker) broadcast() {
+	br.mu.Lock()
+	close(br.signal)
+	hooks := br.hooks
+	br.hooks = nil
+	br.mu.Unlock()
+	for h := hooks; h != nil; h = h.next {
+		h.call()
+	}
+}
+
+func (br *breaker) elapsed() time.Duration {
+	return br.now().Sub(br.started)
+}
+
+func (br *breaker) now() time.Time {
+	return br.clock.Now()
+}
+
+func (br *breaker) cause() error {
+	select {
+	case <-br.signal:
+		return br.reason
+	default:
+		return nil
+	}
+}
+
+// release stores the cause and closes the Done channel.
+func (br *breaker) release(cause error) {
+	br.settle(func() { br.reason = cause })
+}
+
+// settle calls the function once to store the cause and closes the Done channel.
+// The channel is closed outside of the once, so the registered callbacks
+// can close the breaker again without a deadlock.
+func (br *breaker) settle(fn func()) {
+	settled := false
+	br.closer.Do(func() {
+		fn()
+		settled = true
+	})
+	if settled {
+		br.broadcast()
+	}
+}
+
 func (br *breaker) trigger() Interface {
 	return br
 }
 
+func newChannelBreaker(signal <-chan struct{}) *channelBreaker {
+	br := &channelBreaker{}
+	br.lazyBreaker = newLazyBreaker(signal, func() { br.release(ChannelClosed) })
+	return br
+}
+
 type channelBreaker struct {
-	*breaker
-	internal chan struct{}
-	external <-chan struct{}
+	*lazyBreaker
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *channelBreaker) Close() {
-	br.closer.Do(func() { close(br.internal) })
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *channelBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
 }
 
-// trigger starts listening to the internal signal to close the Done channel.
 func (br *channelBreaker) trigger() Interface {
-	go func() {
-		select {
-		case <-br.external:
-			br.Close()
-		case <-br.internal:
+	return br
+}
+
+func newLazyBreaker(external <-chan struct{}, expire func()) *lazyBreaker {
+	return &lazyBreaker{breaker: newBreaker(), external: external, expire: expire}
+}
+
+// lazyBreaker closes the Done channel when the external signal is closed.
+// It starts a goroutine to wait for the signal only when the Done channel
+// is requested, otherwise the signal is checked by the Err and Cause calls.
+type lazyBreaker struct {
+	*breaker
+	watcher  sync.Once
+	external <-chan struct{}
+	expire   func()
+}
+
+// Done returns a channel that's closed when a cancellation signal occurred.
+func (br *lazyBreaker) Done() <-chan struct{} {
+	br.watch()
+	return br.signal
+}
+
+// Err returns a non-nil error if the Done channel is closed and nil otherwise.
+// After Err returns a non-nil error, successive calls to Err return the same error.
+func (br *lazyBreaker) Err() error {
+	br.poll()
+	return br.breaker.Err()
+}
+
+// IsReleased returns true if resources associated with the breaker were released.
+//
+// Deprecated: see the extended interface.
+func (br *lazyBreaker) IsReleased() bool {
+	return br.Err() != nil
+}
+
+func (br *lazyBreaker) afterFunc(fn func()) (stop func()) {
+	br.watch()
+	return br.breaker.afterFunc(fn)
+}
+
+func (br *lazyBreaker) attach(h *hook) bool {
+	br.watch()
+	return br.breaker.attach(h)
+}
+
+func (br *lazyBreaker) cause() error {
+	br.poll()
+	return br.breaker.cause()
+}
+
+// poll closes the Done channel if the external signal is already closed.
+func (br *lazyBreaker) poll() {
+	select {
+	case <-br.external:
+		br.expire()
+	default:
+	}
+}
+
+// watch starts listening to the external signal to close the Done channel
+// if it's not started yet.
+func (br *lazyBreaker) watch() {
+	br.watcher.Do(func() {
+		if br.external == nil || br.breaker.Err() != nil {
+			return
 		}
-		close(br.signal)
-	}()
+		go func() {
+			select {
+			case <-br.external:
+				br.expire()
+			case <-br.signal:
+			}
+		}()
+	})
+}
+
+func (br *lazyBreaker) trigger() Interface {
 	return br
 }
 
+func newContextBreaker(ctx context.Context, cancel func(error)) *contextBreaker {
+	return &contextBreaker{Context: ctx, cancel: cancel, started: time.Now()}
+}
+
 type contextBreaker struct {
 	context.Context
-	cancel context.CancelFunc
+	cancel  func(error)
+	closer  sync.Once
+	mu      sync.Mutex
+	reason  error
+	started time.Time
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *contextBreaker) Close() {
-	br.cancel()
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *contextBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
 }
 
 // IsReleased returns true if resources associated with the breaker were released.
@@ -203,75 +571,168 @@ func (br *contextBreaker) IsReleased() bool {
 	return br.Err() != nil
 }
 
+func (br *contextBreaker) elapsed() time.Duration {
+	return time.Since(br.started)
+}
+
+func (br *contextBreaker) cause() error {
+	err := br.Context.Err()
+	if err == nil {
+		return nil
+	}
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if br.reason == nil {
+		br.reason = contextCause(br.Context)
+	}
+	return br.reason
+}
+
+// release stores the cause if the Context is not done yet and cancels it.
+func (br *contextBreaker) release(cause error) {
+	br.closer.Do(func() {
+		br.mu.Lock()
+		if br.Context.Err() == nil {
+			br.reason = cause
+		}
+		br.mu.Unlock()
+		br.cancel(cause)
+	})
+}
+
 func (br *contextBreaker) trigger() Interface {
 	return br
 }
 
+// breakerContext adapts the breaker to the Context interface.
+type breakerContext struct {
+	Interface
+}
+
+// Deadline returns the time when the breaker will be interrupted.
+// It returns ok==false when the breaker has no deadline.
+func (ctx breakerContext) Deadline() (time.Time, bool) {
+	return deadline(ctx.Interface)
+}
+
+// Err returns nil if the Done channel is not closed yet,
+// context.DeadlineExceeded if the breaker was interrupted by time
+// and context.Canceled otherwise.
+func (ctx breakerContext) Err() error {
+	if ctx.Interface.Err() == nil {
+		return nil
+	}
+	switch err := Cause(ctx.Interface).(type) {
+	case *ContextError:
+		return err.Err
+	case *DeadlineError, *TimeoutError:
+		return context.DeadlineExceeded
+	default:
+		return context.Canceled
+	}
+}
+
+// Value returns nil, the breaker doesn't carry any values.
+func (ctx breakerContext) Value(interface{}) interface{} {
+	return nil
+}
+
+// String returns the string representation of the Context.
+func (ctx breakerContext) String() string {
+	return "breaker.ToContext"
+}
+
 func newSignalBreaker(signals []os.Signal) *signalBreaker {
-	return &signalBreaker{newBreaker(), make(chan struct{}), make(chan os.Signal, len(signals)), signals}
+	br := &signalBreaker{breaker: newBreaker()}
+	br.subscription = &subscription{signals: signals, notify: func(sig os.Signal) {
+		br.release(&SignalError{sig})
+	}}
+	return br
 }
 
 type signalBreaker struct {
 	*breaker
-	internal chan struct{}
-	external chan os.Signal
-	signals  []os.Signal
+	subscription *subscription
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *signalBreaker) Close() {
-	br.closer.Do(func() { close(br.internal) })
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *signalBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// release stores the cause, stops listening to the signals
+// and closes the Done channel.
+func (br *signalBreaker) release(cause error) {
+	br.settle(func() {
+		br.reason = cause
+		notifier.unsubscribe(br.subscription)
+	})
 }
 
 // trigger starts listening to the required signals to close the Done channel.
 func (br *signalBreaker) trigger() Interface {
-	go func() {
-		signal.Notify(br.external, br.signals...)
-		select {
-		case <-br.external:
-			br.Close()
-		case <-br.internal:
-		}
-		signal.Stop(br.external)
-		close(br.external)
-		close(br.signal)
-	}()
+	notifier.subscribe(br.subscription)
 	return br
 }
 
-func newTimeoutBreaker(timeout time.Duration) *timeoutBreaker {
-	return &timeoutBreaker{newBreaker(), make(chan struct{}), time.NewTimer(timeout)}
+func newTimeoutBreaker(clock Clock, deadline time.Time, timeout time.Duration, expired error) *timeoutBreaker {
+	br := &timeoutBreaker{breaker: newClockBreaker(clock), deadline: deadline, expired: expired}
+	br.external = clock.AfterFunc(timeout, br.expire)
+	return br
 }
 
 type timeoutBreaker struct {
 	*breaker
-	internal chan struct{}
-	external *time.Timer
+	external Timer
+	deadline time.Time
+	expired  error
+}
+
+// Deadline returns the time when the Done channel will be closed.
+func (br *timeoutBreaker) Deadline() (time.Time, bool) {
+	return br.deadline, true
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *timeoutBreaker) Close() {
-	br.closer.Do(func() { close(br.internal) })
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *timeoutBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// expire stores the cause of the timeout and closes the Done channel.
+// It's called by the timer, so it doesn't touch it.
+func (br *timeoutBreaker) expire() {
+	br.settle(func() { br.reason = br.expired })
 }
 
-// trigger starts listening to the internal timer to close the Done channel.
+// release stores the cause, stops the timer and closes the Done channel.
+func (br *timeoutBreaker) release(cause error) {
+	br.settle(func() {
+		br.reason = cause
+		br.external.Stop()
+	})
+}
+
+// trigger does nothing, the timer closes the Done channel by itself.
 func (br *timeoutBreaker) trigger() Interface {
-	go func() {
-		select {
-		case <-br.external.C:
-			br.Close()
-		case <-br.internal:
-		}
-		stop(br.external)
-		close(br.signal)
-	}()
 	return br
 }
 
-func stop(timer *time.Timer) {
+func stop(timer Timer) {
 	if !timer.Stop() {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 		default:
 		}
 	}
@@ -292,19 +292,369 @@ func TestBreakByTimeout(t *testing.T) {
 	})
 }
 
-func TestToContext(t *testing.T) {
-	br := BreakByTimeout(time.Hour)
+func TestCause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not released", func(t *testing.T) {
+		t.Parallel()
+
+		br := New()
+		if Cause(br) != nil {
+			t.Error("a breaker has cause")
+		}
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		brs := []Interface{
+			New(),
+			BreakByChannel(make(chan struct{})),
+			BreakByContext(context.WithCancel(context.TODO())),
+			BreakByDeadline(time.Now().Add(time.Hour)),
+			BreakBySignal(os.Kill),
+			BreakByTimeout(time.Hour),
+			Multiplex(BreakByTimeout(time.Hour)),
+		}
+		for _, br := range brs {
+			br.Close()
+			<-br.Done()
+			if Cause(br) != Closed {
+				t.Errorf("unexpected cause %#v", Cause(br))
+			}
+		}
+	})
+
+	t.Run("close channel", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan struct{})
+		br := BreakByChannel(ch)
+
+		close(ch)
+		<-br.Done()
+		if Cause(br) != ChannelClosed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		br := BreakByContext(ctx, cancel)
+
+		cancel()
+		<-br.Done()
+		if err, is := Cause(br).(*ContextError); !is || err.Err != context.Canceled {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
 
-	ctx := ToContext(br)
-	if ctx.Done() == nil {
-		t.Error("bad context")
+	t.Run("deadline occurred", func(t *testing.T) {
+		t.Parallel()
+
+		deadline := time.Now().Add(delta)
+		br := BreakByDeadline(deadline)
+
+		<-br.Done()
+		if err, is := Cause(br).(*DeadlineError); !is || !err.Deadline.Equal(deadline) {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("deadline has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		deadline := time.Now().Add(-delta)
+		br := BreakByDeadline(deadline)
+		if err, is := Cause(br).(*DeadlineError); !is || !err.Deadline.Equal(deadline) {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("signal received", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakBySignal(syscall.SIGCHLD)
+		go func() {
+			proc, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				t.Error(err)
+			}
+			err = proc.Signal(syscall.SIGCHLD)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+		select {
+		case <-br.Done():
+		case <-time.After(delta):
+			br.Close()
+			t.Skip("not stable test case")
+		}
+
+		if err, is := Cause(br).(*SignalError); !is || err.Signal != syscall.SIGCHLD {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("timeout happened", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByTimeout(delta)
+
+		<-br.Done()
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != delta {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("multiplexed breakers", func(t *testing.T) {
+		t.Parallel()
+
+		br := Multiplex(
+			BreakBySignal(os.Kill),
+			BreakByTimeout(time.Hour),
+			BreakByTimeout(delta),
+			BreakByDeadline(time.Now().Add(time.Hour)),
+		)
+
+		<-br.Done()
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != delta {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+}
+
+func TestCloseWithCause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with cause", func(t *testing.T) {
+		t.Parallel()
+
+		cause := Error("fatal error")
+		brs := []Interface{
+			New(),
+			BreakByChannel(make(chan struct{})),
+			BreakByContext(context.WithCancel(context.TODO())),
+			BreakByDeadline(time.Now().Add(time.Hour)),
+			BreakBySignal(os.Kill),
+			BreakByTimeout(time.Hour),
+			Multiplex(BreakByTimeout(time.Hour)),
+		}
+		for _, br := range brs {
+			CloseWithCause(br, cause)
+			checkBreakerIsReleased(t, br)
+			if Cause(br) != cause {
+				t.Errorf("unexpected cause %#v", Cause(br))
+			}
+
+			CloseWithCause(br, Closed)
+			if Cause(br) != cause {
+				t.Errorf("unexpected cause %#v", Cause(br))
+			}
+		}
+	})
+
+	t.Run("without cause", func(t *testing.T) {
+		t.Parallel()
+
+		br := New()
+		CloseWithCause(br, nil)
+		checkBreakerIsReleasedFast(t, br)
+		if Cause(br) != Closed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("propagate cause", func(t *testing.T) {
+		t.Parallel()
+
+		cause := Error("fatal error")
+		child := BreakByTimeout(time.Hour)
+		br := Multiplex(child, BreakBySignal(os.Kill))
+		CloseWithCause(br, cause)
+		checkBreakerIsReleased(t, child)
+		if Cause(child) != cause {
+			t.Errorf("unexpected cause %#v", Cause(child))
+		}
+	})
+}
+
+func TestRemaining(t *testing.T) {
+	t.Parallel()
+
+	t.Run("time-based breakers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Hour)
+		brs := []Interface{
+			BreakByContext(ctx, cancel),
+			BreakByDeadline(time.Now().Add(time.Hour)),
+			BreakByTimeout(time.Hour),
+			Multiplex(BreakBySignal(os.Kill), BreakByTimeout(2*time.Hour), BreakByTimeout(time.Hour)),
+		}
+		for _, br := range brs {
+			if left := Remaining(br); left > time.Hour || left < time.Hour-delta {
+				t.Errorf("unexpected remaining time %v", left)
+			}
+			br.Close()
+			<-br.Done()
+			if left := Remaining(br); left != 0 {
+				t.Errorf("unexpected remaining time %v", left)
+			}
+		}
+	})
+
+	t.Run("without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		br := Multiplex(New(), BreakBySignal(os.Kill))
+		if Remaining(br) != NoDeadline {
+			t.Error("a breaker has unexpected deadline")
+		}
+		br.Close()
+		<-br.Done()
+		if Remaining(br) != 0 {
+			t.Error("a released breaker has remaining time")
+		}
+	})
+}
+
+func TestElapsed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	brs := []Interface{
+		New(),
+		BreakByContext(ctx, cancel),
+		BreakByTimeout(time.Hour),
+		Multiplex(BreakByTimeout(time.Hour)),
 	}
-	if ctx.Err() != nil {
-		t.Error("bad context")
+	time.Sleep(delta)
+	for _, br := range brs {
+		if elapsed := Elapsed(br); elapsed < delta || elapsed > 5*delta {
+			t.Errorf("unexpected elapsed time %v", elapsed)
+		}
+		br.Close()
+	}
+}
+
+func TestToContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByTimeout(time.Hour)
+
+		ctx := ToContext(br)
+		if ctx.Done() == nil {
+			t.Error("bad context")
+		}
+		if ctx.Done() != br.Done() {
+			t.Error("a context has different Done channel")
+		}
+		if ctx.Err() != nil {
+			t.Error("bad context")
+		}
+		if ctx.Value(ctx) != nil {
+			t.Error("a context has unexpected value")
+		}
+
+		br.Close()
+		<-ctx.Done()
+		checkContextIsDone(t, ctx)
+	})
+
+	t.Run("propagate deadline", func(t *testing.T) {
+		t.Parallel()
+
+		deadline := time.Now().Add(time.Hour)
+		br := Multiplex(
+			BreakBySignal(os.Kill),
+			BreakByDeadline(deadline),
+			BreakByTimeout(2*time.Hour),
+		)
+		defer br.Close()
+
+		ctx := ToContext(br)
+		if at, ok := ctx.Deadline(); !ok || !at.Equal(deadline) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		br := Multiplex(BreakBySignal(os.Kill), BreakByTimeout(delta))
+
+		ctx := ToContext(br)
+		<-ctx.Done()
+		if ctx.Err() != context.DeadlineExceeded {
+			t.Errorf("unexpected error %#v", ctx.Err())
+		}
+	})
+
+	t.Run("without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		br := Multiplex(BreakBySignal(os.Kill), New())
+		defer br.Close()
+
+		if _, ok := ToContext(br).Deadline(); ok {
+			t.Error("a context has unexpected deadline")
+		}
+	})
+
+	t.Run("derive context", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByTimeout(time.Hour)
+
+		ctx, cancel := context.WithCancel(ToContext(br))
+		defer cancel()
+
+		br.Close()
+		<-ctx.Done()
+		checkContextIsDone(t, ctx)
+	})
+
+	t.Run("context breaker", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+		ctx, cancel := context.WithCancel(context.WithValue(context.TODO(), key{}, "value"))
+		br := BreakByContext(ctx, cancel)
+
+		ctx = ToContext(br)
+		if ctx.Value(key{}) != "value" {
+			t.Error("a context lost its value")
+		}
+
+		br.Close()
+		<-ctx.Done()
+		checkContextIsDone(t, ctx)
+	})
+}
+
+// BenchmarkToContext expects one allocation of the adapter per call.
+func BenchmarkToContext(b *testing.B) {
+	br := New()
+	defer br.Close()
+
+	if allocs := testing.AllocsPerRun(10, func() { _ = ToContext(br) }); allocs > 1 {
+		b.Errorf("unexpected number of allocations %v", allocs)
 	}
 
-	br.Close()
-	<-ctx.Done()
+	var ctx context.Context
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx = ToContext(br)
+	}
+	_ = ctx
 }
 
 // helpers
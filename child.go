@@ -0,0 +1,22 @@
+package breaker
+
+// Child binds the child breaker to the parent one and returns the child.
+// Closing the parent cascades to the child with the parent's cause,
+// while closing the child leaves the parent alone, like the context.WithCancel does.
+// The binding doesn't start any goroutine if the parent comes from this package.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  http.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+//  	interrupter := breaker.Child(shutdown, breaker.BreakByTimeout(time.Second))
+//  	defer interrupter.Close()
+//
+//  	background.Job().Do(interrupter)
+//  })
+//
+func Child(parent, child Interface) Interface {
+	unbind := afterFunc(parent, func() { CloseWithCause(child, Cause(parent)) })
+	afterFunc(child, unbind)
+	return child
+}
@@ -0,0 +1,84 @@
+package breaker_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestChild(t *testing.T) {
+	t.Parallel()
+
+	t.Run("close parent", func(t *testing.T) {
+		t.Parallel()
+
+		parent := New()
+		children := []Interface{
+			Child(parent, New()),
+			Child(parent, BreakByChannel(make(chan struct{}))),
+			Child(parent, BreakByContext(context.WithCancel(context.TODO()))),
+			Child(parent, BreakBySignal(os.Kill)),
+			Child(parent, BreakByTimeout(time.Hour)),
+			Child(parent, Multiplex(BreakByTimeout(time.Hour))),
+		}
+		for _, child := range children {
+			checkBreakerIsNotReleased(t, child)
+		}
+
+		cause := Error("shutdown")
+		CloseWithCause(parent, cause)
+		for _, child := range children {
+			checkBreakerIsReleased(t, child)
+			if Cause(child) != cause {
+				t.Errorf("unexpected cause %#v", Cause(child))
+			}
+		}
+	})
+
+	t.Run("close child", func(t *testing.T) {
+		t.Parallel()
+
+		parent := BreakByTimeout(time.Hour)
+		child := Child(parent, New())
+
+		child.Close()
+		checkBreakerIsReleased(t, child)
+		checkBreakerIsNotReleased(t, parent)
+		parent.Close()
+	})
+
+	t.Run("nested children", func(t *testing.T) {
+		t.Parallel()
+
+		parent := BreakByTimeout(delta)
+		child := Child(parent, New())
+		grandchild := Child(child, BreakByTimeout(time.Hour))
+
+		checkBreakerIsReleased(t, grandchild)
+		if _, is := Cause(grandchild).(*TimeoutError); !is {
+			t.Errorf("unexpected cause %#v", Cause(grandchild))
+		}
+	})
+
+	t.Run("released parent", func(t *testing.T) {
+		t.Parallel()
+
+		parent := BreakByTimeout(-delta)
+		child := Child(parent, New())
+		checkBreakerIsReleasedFast(t, child)
+	})
+
+	t.Run("foreign parent", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		parent := Custom(done, nil, nil)
+		child := Child(BreakByContext(context.WithCancel(ToContext(parent))), New())
+
+		close(done)
+		checkBreakerIsReleased(t, child)
+	})
+}
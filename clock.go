@@ -0,0 +1,256 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides time-based breakers with the current time and timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a new Timer that will send
+	// the current time on its channel after at least the duration.
+	NewTimer(time.Duration) Timer
+	// AfterFunc waits for the duration to elapse and then calls the function.
+	// The returned Timer can be used to cancel the call, its channel is not used.
+	AfterFunc(time.Duration, func()) Timer
+}
+
+// Timer represents a single event like the time.Timer does.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing.
+	// It returns false if the timer has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after the duration.
+	// It returns false if the timer had expired or been stopped.
+	Reset(time.Duration) bool
+}
+
+// WithClock returns constructors of time-based breakers using the clock.
+//
+//  clock := breaker.NewFakeClock(time.Now())
+//  interrupter := breaker.WithClock(clock).BreakByTimeout(time.Minute)
+//  defer interrupter.Close()
+//
+//  clock.Advance(time.Minute)
+//  <-interrupter.Done()
+//
+func WithClock(clock Clock) Clocked {
+	return Clocked{clock}
+}
+
+// Clocked provides constructors of time-based breakers using the same clock.
+type Clocked struct {
+	clock Clock
+}
+
+// BreakByDeadline closes the Done channel when the deadline occurs.
+func (c Clocked) BreakByDeadline(deadline time.Time) Interface {
+	timeout := deadline.Sub(c.clock.Now())
+	if timeout < 0 {
+		return closedBreaker(&DeadlineError{deadline})
+	}
+	return newTimeoutBreaker(c.clock, deadline, timeout, &DeadlineError{deadline}).trigger()
+}
+
+// BreakByIdle closes the Done channel when no activity happens
+// during the idle timeout.
+func (c Clocked) BreakByIdle(timeout time.Duration) Resettable {
+	br := newIdleBreaker(c.clock, timeout)
+	if timeout < 0 {
+		br.release(&TimeoutError{timeout})
+		return br
+	}
+	br.trigger()
+	return br
+}
+
+// BreakByTimeout closes the Done channel when the timeout happens.
+func (c Clocked) BreakByTimeout(timeout time.Duration) Interface {
+	if timeout < 0 {
+		return closedBreaker(&TimeoutError{timeout})
+	}
+	return newTimeoutBreaker(c.clock, c.clock.Now().Add(timeout), timeout, &TimeoutError{timeout}).trigger()
+}
+
+// NewFakeClock returns a new Clock, which time stays still
+// until it is moved by the Advance call.
+//
+//  clock := breaker.NewFakeClock(time.Now())
+//  interrupter := breaker.WithClock(clock).BreakByTimeout(time.Minute)
+//
+//  go retry.Do(interrupter, action)
+//
+//  clock.Advance(time.Minute)
+//  <-interrupter.Done()
+//
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, timers: make(map[*fakeTimer]struct{})}
+}
+
+// FakeClock is a Clock for deterministic tests of time-based breakers.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers map[*fakeTimer]struct{}
+	seq    uint64
+}
+
+// Advance moves the current time and fires the expired timers
+// in the order of their deadlines, ties are broken by the order of creation.
+// The current time is stepped to the deadline of each timer before it fires,
+// and the functions of expired timers are called before it returns.
+func (clock *FakeClock) Advance(d time.Duration) {
+	clock.mu.Lock()
+	target := clock.now.Add(d)
+	for {
+		timer := clock.next(target)
+		if timer == nil {
+			break
+		}
+		delete(clock.timers, timer)
+		if timer.at.After(clock.now) {
+			clock.now = timer.at
+		}
+		call := timer.fire(clock.now)
+		if call == nil {
+			continue
+		}
+		clock.mu.Unlock()
+		call()
+		clock.mu.Lock()
+	}
+	if target.After(clock.now) {
+		clock.now = target
+	}
+	clock.mu.Unlock()
+}
+
+// next returns the earliest timer expiring not after the time,
+// the clock must be locked.
+func (clock *FakeClock) next(until time.Time) *fakeTimer {
+	var earliest *fakeTimer
+	for timer := range clock.timers {
+		if timer.at.After(until) {
+			continue
+		}
+		if earliest == nil || timer.at.Before(earliest.at) ||
+			timer.at.Equal(earliest.at) && timer.seq < earliest.seq {
+			earliest = timer
+		}
+	}
+	return earliest
+}
+
+// Now returns the current time.
+func (clock *FakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+// NewTimer creates a new Timer that will send
+// the current time on its channel after the clock is advanced by the duration.
+func (clock *FakeClock) NewTimer(d time.Duration) Timer {
+	timer := &fakeTimer{clock: clock, c: make(chan time.Time, 1), seq: clock.sequence()}
+	timer.Reset(d)
+	return timer
+}
+
+// AfterFunc waits for the clock to be advanced by the duration
+// and then calls the function. The function of a non-positive duration
+// is called in its own goroutine like the time.AfterFunc does.
+func (clock *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	timer := &fakeTimer{clock: clock, fn: fn, seq: clock.sequence()}
+	timer.Reset(d)
+	return timer
+}
+
+// sequence returns the next number in the order of timer creation.
+func (clock *FakeClock) sequence() uint64 {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	clock.seq++
+	return clock.seq
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	c     chan time.Time
+	fn    func()
+	at    time.Time
+	seq   uint64
+}
+
+// C returns the channel on which the time is delivered.
+func (timer *fakeTimer) C() <-chan time.Time {
+	return timer.c
+}
+
+// Stop prevents the Timer from firing.
+func (timer *fakeTimer) Stop() bool {
+	timer.clock.mu.Lock()
+	defer timer.clock.mu.Unlock()
+	_, active := timer.clock.timers[timer]
+	delete(timer.clock.timers, timer)
+	return active
+}
+
+// Reset changes the timer to expire after the duration.
+func (timer *fakeTimer) Reset(d time.Duration) bool {
+	var call func()
+	timer.clock.mu.Lock()
+	_, active := timer.clock.timers[timer]
+	timer.at = timer.clock.now.Add(d)
+	if d > 0 {
+		timer.clock.timers[timer] = struct{}{}
+	} else {
+		delete(timer.clock.timers, timer)
+		call = timer.fire(timer.clock.now)
+	}
+	timer.clock.mu.Unlock()
+	if call != nil {
+		go call()
+	}
+	return active
+}
+
+// fire sends the current time on the channel
+// or returns the function to call outside the lock.
+func (timer *fakeTimer) fire(now time.Time) func() {
+	if timer.fn != nil {
+		return timer.fn
+	}
+	select {
+	case timer.c <- now:
+	default:
+	}
+	return nil
+}
+
+var systemClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return realTimer{time.AfterFunc(d, fn)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (timer realTimer) C() <-chan time.Time {
+	return timer.Timer.C
+}
@@ -0,0 +1,169 @@
+package breaker_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timeout happened", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := WithClock(clock).BreakByTimeout(time.Hour)
+		checkBreakerIsNotReleased(t, br)
+		if left := Remaining(br); left != time.Hour {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		clock.Advance(time.Hour - time.Nanosecond)
+		if left, elapsed := Remaining(br), Elapsed(br); left != time.Nanosecond || elapsed != time.Hour-time.Nanosecond {
+			t.Errorf("unexpected remaining time %v and elapsed time %v", left, elapsed)
+		}
+		checkBreakerIsNotReleased(t, br)
+
+		clock.Advance(time.Nanosecond)
+		checkBreakerIsReleased(t, br)
+		if _, is := Cause(br).(*TimeoutError); !is {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("timeout has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := WithClock(clock).BreakByTimeout(-time.Hour)
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("deadline occurred", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		deadline := clock.Now().Add(time.Hour)
+		br := WithClock(clock).BreakByDeadline(deadline)
+		checkBreakerIsNotReleased(t, br)
+
+		clock.Advance(2 * time.Hour)
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*DeadlineError); !is || !err.Deadline.Equal(deadline) {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("deadline has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := WithClock(clock).BreakByDeadline(clock.Now().Add(-time.Hour))
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("idle timeout", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := WithClock(clock).BreakByIdle(time.Minute)
+
+		for range make([]struct{}, times) {
+			clock.Advance(time.Minute / 2)
+			br.Touch()
+		}
+		checkBreakerIsNotReleased(t, br)
+
+		br.Extend(time.Minute)
+		clock.Advance(time.Minute)
+		if left := Remaining(br); left != time.Minute {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		clock.Advance(time.Minute)
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("idle timeout has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := WithClock(clock).BreakByIdle(-time.Minute)
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("multiplexed breakers", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		br := Multiplex(
+			BreakBySignal(os.Kill),
+			WithClock(clock).BreakByTimeout(2*time.Hour),
+			WithClock(clock).BreakByTimeout(time.Hour),
+		)
+		if left := Remaining(br); left != time.Hour {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		clock.Advance(time.Hour)
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("timeouts in order", func(t *testing.T) {
+		t.Parallel()
+
+		for range make([]struct{}, 100) {
+			clock := NewFakeClock(time.Now())
+			first := WithClock(clock).BreakByTimeout(time.Second)
+			br := Multiplex(WithClock(clock).BreakByTimeout(2*time.Second), first)
+
+			clock.Advance(3 * time.Second)
+			checkBreakerIsReleased(t, br)
+			if which, _ := Which(br); which != first {
+				t.Fatal("a later timeout fired first")
+			}
+		}
+	})
+}
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	clock := NewFakeClock(now)
+
+	timer := clock.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Error("an active timer is not stopped")
+	}
+	if timer.Stop() {
+		t.Error("a stopped timer is stopped again")
+	}
+	if timer.Reset(time.Second) {
+		t.Error("a stopped timer is reset as active")
+	}
+
+	clock.Advance(time.Second)
+	if at := <-timer.C(); !at.Equal(now.Add(time.Second)) {
+		t.Errorf("unexpected time %v", at)
+	}
+
+	timer.Reset(0)
+	if at := <-timer.C(); !at.Equal(clock.Now()) {
+		t.Errorf("unexpected time %v", at)
+	}
+
+	var seen []time.Time
+	clock.AfterFunc(2*time.Second, func() { seen = append(seen, clock.Now()) })
+	clock.AfterFunc(time.Second, func() { seen = append(seen, clock.Now()) })
+	clock.Advance(3 * time.Second)
+	if len(seen) != 2 || !seen[0].Equal(now.Add(2*time.Second)) || !seen[1].Equal(now.Add(3*time.Second)) {
+		t.Errorf("unexpected times %v", seen)
+	}
+	if !clock.Now().Equal(now.Add(4 * time.Second)) {
+		t.Errorf("unexpected time %v", clock.Now())
+	}
+}
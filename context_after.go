@@ -0,0 +1,12 @@
+// +build go1.21
+
+package breaker
+
+import "context"
+
+// afterFunc registers the callback to call after the Context is done
+// and returns the function to unregister it.
+func (br *contextBreaker) afterFunc(fn func()) (stop func()) {
+	unregister := context.AfterFunc(br.Context, fn)
+	return func() { unregister() }
+}
@@ -0,0 +1,26 @@
+// +build go1.20
+
+package breaker
+
+import "context"
+
+// BreakByContextCause returns a new breaker based on the Context
+// and its CancelCauseFunc. The cause passed to the CloseWithCause
+// is propagated to the Context and available by the context.Cause call.
+//
+//  ctx, cancel := context.WithCancelCause(req.Context())
+//  interrupter := breaker.BreakByContextCause(ctx, cancel)
+//  defer interrupter.Close()
+//
+//  background.Job().Do(interrupter)
+//
+func BreakByContextCause(ctx context.Context, cancel context.CancelCauseFunc) Interface {
+	return newContextBreaker(ctx, cancel).trigger()
+}
+
+func contextCause(ctx context.Context) error {
+	if cause := context.Cause(ctx); cause != nil && cause != ctx.Err() {
+		return cause
+	}
+	return &ContextError{ctx.Err()}
+}
@@ -0,0 +1,9 @@
+// +build !go1.20
+
+package breaker
+
+import "context"
+
+func contextCause(ctx context.Context) error {
+	return &ContextError{ctx.Err()}
+}
@@ -0,0 +1,58 @@
+// +build go1.20
+
+package breaker_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestBreakByContextCause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancel context with cause", func(t *testing.T) {
+		t.Parallel()
+
+		cause := Error("fatal error")
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		br := BreakByContextCause(ctx, cancel)
+		checkBreakerIsNotReleased(t, br)
+
+		cancel(cause)
+		checkBreakerIsReleasedFast(t, br)
+		if Cause(br) != cause {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("close breaker with cause", func(t *testing.T) {
+		t.Parallel()
+
+		cause := Error("fatal error")
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		br := BreakByContextCause(ctx, cancel)
+		checkBreakerIsNotReleased(t, br)
+
+		CloseWithCause(br, cause)
+		checkBreakerIsReleasedFast(t, br)
+		checkContextIsDone(t, ctx)
+		if context.Cause(ctx) != cause {
+			t.Errorf("unexpected context cause %#v", context.Cause(ctx))
+		}
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		br := BreakByContextCause(ctx, cancel)
+
+		br.Close()
+		checkBreakerIsReleasedFast(t, br)
+		if context.Cause(ctx) != Closed {
+			t.Errorf("unexpected context cause %#v", context.Cause(ctx))
+		}
+	})
+}
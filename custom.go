@@ -0,0 +1,66 @@
+package breaker
+
+// Custom returns a new breaker based on a third-party source of cancellation,
+// e.g. a message broker rebalance or a feature flag flip.
+// The breaker closes its Done channel when the done channel is closed
+// or the breaker is closed explicitly, so the Done channel is always valid.
+// The cleanup, if present, is called once to release resources of the source,
+// and the failure, if present, provides the cause of interruption
+// returned by the Cause call when the done channel was closed.
+// Like the BreakByChannel does, it doesn't start a goroutine
+// until the Done channel of the breaker is requested.
+//
+//  subscription := kafka.Subscribe(topic)
+//  interrupter := breaker.Custom(subscription.Rebalanced(), subscription.Close, subscription.Err)
+//  defer interrupter.Close()
+//
+//  background.Job().Do(breaker.Multiplex(interrupter, breaker.BreakByTimeout(time.Minute)))
+//
+func Custom(done <-chan struct{}, cleanup func(), failure func() error) Interface {
+	br := &customBreaker{cleanup: cleanup, failure: failure}
+	br.lazyBreaker = newLazyBreaker(done, br.expire)
+	return br.trigger()
+}
+
+type customBreaker struct {
+	*lazyBreaker
+	cleanup func()
+	failure func() error
+}
+
+// Close closes the Done channel and releases resources associated with it.
+func (br *customBreaker) Close() {
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *customBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// expire closes the Done channel with the cause provided by the source.
+func (br *customBreaker) expire() {
+	var cause error = ChannelClosed
+	if br.failure != nil {
+		if err := br.failure(); err != nil {
+			cause = err
+		}
+	}
+	br.release(cause)
+}
+
+// release stores the cause, releases resources of the source
+// and closes the Done channel.
+func (br *customBreaker) release(cause error) {
+	br.settle(func() {
+		br.reason = cause
+		if br.cleanup != nil {
+			br.cleanup()
+		}
+	})
+}
+
+func (br *customBreaker) trigger() Interface {
+	return br
+}
@@ -0,0 +1,88 @@
+package breaker_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestCustom(t *testing.T) {
+	t.Parallel()
+
+	t.Run("close source", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+		cause := Error("rebalanced")
+		done := make(chan struct{})
+		br := Custom(done, func() { atomic.AddInt32(&calls, 1) }, func() error { return cause })
+		checkBreakerIsNotReleased(t, br)
+
+		close(done)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != cause {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+
+		br.Close()
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Errorf("cleanup was called %d times", calls)
+		}
+	})
+
+	t.Run("close source without failure", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		br := Custom(done, nil, func() error { return nil })
+
+		close(done)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != ChannelClosed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+		br := Custom(make(chan struct{}), func() { atomic.AddInt32(&calls, 1) }, nil)
+		checkBreakerIsNotReleased(t, br)
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != Closed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Errorf("cleanup was called %d times", calls)
+		}
+	})
+
+	t.Run("without source", func(t *testing.T) {
+		t.Parallel()
+
+		br := Custom(nil, nil, nil)
+		checkBreakerIsNotReleased(t, br)
+
+		br.Close()
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("multiplex", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		source := Custom(done, nil, nil)
+		br := Multiplex(source, BreakByTimeout(time.Hour))
+
+		close(done)
+		checkBreakerIsReleased(t, br)
+		if which, _ := Which(br); which != source {
+			t.Error("unexpected breaker interrupted the multiplexed one")
+		}
+	})
+}
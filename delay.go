@@ -0,0 +1,28 @@
+package breaker
+
+import "time"
+
+// Delay returns a new breaker that closes the Done channel
+// the duration after the source breaker is interrupted,
+// or immediately if it's closed explicitly. It doesn't close the source.
+// The cause of interruption is the cause of the source.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  hardStop := breaker.Delay(shutdown, 10*time.Second)
+//  defer hardStop.Close()
+//
+//  go server.Serve(listener)
+//  <-shutdown.Done()              // stop accepting new requests
+//  server.Drain(hardStop)         // give in-flight requests ten seconds
+//
+func Delay(source Interface, d time.Duration) Interface {
+	br := newBreaker()
+	unbind := afterFunc(source, func() {
+		timeout := Child(br, BreakByTimeout(d))
+		afterFunc(timeout, func() { br.release(Cause(source)) })
+	})
+	br.afterFunc(unbind)
+	return br
+}
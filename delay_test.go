@@ -0,0 +1,72 @@
+package breaker_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("close source", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		source := New()
+		br := Delay(source, timeout)
+		checkBreakerIsNotReleased(t, br)
+
+		start := time.Now()
+		cause := Error("shutdown")
+		CloseWithCause(source, cause)
+		<-br.Done()
+
+		checkDuration(t, start.Add(timeout), time.Now())
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != cause {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("interrupted source", func(t *testing.T) {
+		t.Parallel()
+
+		br := Delay(BreakByTimeout(-delta), -delta)
+		checkBreakerIsReleased(t, br)
+		if _, is := Cause(br).(*TimeoutError); !is {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		source := BreakBySignal(os.Kill)
+		br := Delay(source, time.Hour)
+		checkBreakerIsNotReleased(t, br)
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleasedFast(t, br)
+		checkBreakerIsNotReleased(t, source)
+		source.Close()
+	})
+
+	t.Run("close breaker during delay", func(t *testing.T) {
+		t.Parallel()
+
+		source := New()
+		br := Delay(source, time.Hour)
+
+		source.Close()
+		checkBreakerIsNotReleased(t, br)
+
+		br.Close()
+		checkBreakerIsReleasedFast(t, br)
+		if Cause(br) != Closed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+}
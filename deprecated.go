@@ -15,7 +15,7 @@ import "context"
 // Deprecated: Multiplex has the same optimization under the hood now.
 // It will be removed at v2.
 func MultiplexTwo(one, two Interface) Interface {
-	return newMultiplexedBreaker([]Interface{one, two, stub{}}).trigger()
+	return newMultiplexedBreaker([]Interface{one, two, stub{}}, 1).trigger()
 }
 
 // MultiplexThree combines three breakers into one.
@@ -33,7 +33,7 @@ func MultiplexTwo(one, two Interface) Interface {
 // Deprecated: Multiplex has the same optimization under the hood now.
 // It will be removed at v2.
 func MultiplexThree(one, two, three Interface) Interface {
-	return newMultiplexedBreaker([]Interface{one, two, three}).trigger()
+	return newMultiplexedBreaker([]Interface{one, two, three}, 1).trigger()
 }
 
 // WithContext returns a new breaker and an associated Context based on the passed one.
@@ -47,5 +47,5 @@ func MultiplexThree(one, two, three Interface) Interface {
 // It will be removed at v2.
 func WithContext(ctx context.Context) (Interface, context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
-	return (&contextBreaker{ctx, cancel}).trigger(), ctx
+	return newContextBreaker(ctx, func(error) { cancel() }).trigger(), ctx
 }
@@ -1,9 +1,21 @@
 package breaker
 
+import (
+	"os"
+	"time"
+)
+
 // Interrupted is the error returned by the breaker
 // when a cancellation signal occurred.
 const Interrupted Error = "operation interrupted"
 
+// Closed is the cause of interruption by an explicit Close call.
+const Closed Error = "operation interrupted: breaker closed"
+
+// ChannelClosed is the cause of interruption by a closed channel
+// passed to the BreakByChannel.
+const ChannelClosed Error = "operation interrupted: channel closed"
+
 // Error defines the package errors.
 type Error string
 
@@ -11,3 +23,81 @@ type Error string
 func (err Error) Error() string {
 	return string(err)
 }
+
+// Unwrap returns Interrupted for all package errors except itself,
+// so they can be matched by errors.Is(err, Interrupted).
+func (err Error) Unwrap() error {
+	if err == Interrupted {
+		return nil
+	}
+	return Interrupted
+}
+
+// ContextError is the cause of interruption by a done Context
+// passed to the BreakByContext.
+type ContextError struct {
+	Err error
+}
+
+// Error returns the string representation of an error.
+func (err *ContextError) Error() string {
+	return string(Interrupted) + ": " + err.Err.Error()
+}
+
+// Is reports whether the target is Interrupted.
+func (err *ContextError) Is(target error) bool {
+	return target == Interrupted
+}
+
+// Unwrap returns the Context error.
+func (err *ContextError) Unwrap() error {
+	return err.Err
+}
+
+// DeadlineError is the cause of interruption by a deadline
+// passed to the BreakByDeadline.
+type DeadlineError struct {
+	Deadline time.Time
+}
+
+// Error returns the string representation of an error.
+func (err *DeadlineError) Error() string {
+	return string(Interrupted) + ": deadline " + err.Deadline.Format(time.RFC3339Nano) + " exceeded"
+}
+
+// Unwrap returns Interrupted.
+func (err *DeadlineError) Unwrap() error {
+	return Interrupted
+}
+
+// SignalError is the cause of interruption by an OS signal
+// passed to the BreakBySignal.
+type SignalError struct {
+	Signal os.Signal
+}
+
+// Error returns the string representation of an error.
+func (err *SignalError) Error() string {
+	return string(Interrupted) + ": signal " + err.Signal.String() + " received"
+}
+
+// Unwrap returns Interrupted.
+func (err *SignalError) Unwrap() error {
+	return Interrupted
+}
+
+// TimeoutError is the cause of interruption by a timeout
+// passed to the BreakByTimeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error returns the string representation of an error.
+func (err *TimeoutError) Error() string {
+	return string(Interrupted) + ": timeout " + err.Timeout.String() + " exceeded"
+}
+
+// Unwrap returns Interrupted.
+func (err *TimeoutError) Unwrap() error {
+	return Interrupted
+}
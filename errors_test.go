@@ -3,9 +3,12 @@
 package breaker_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	. "github.com/kamilsk/breaker"
 )
@@ -15,3 +18,26 @@ func TestError(t *testing.T) {
 		t.Error("fail error assertion")
 	}
 }
+
+func TestCauseError(t *testing.T) {
+	causes := []error{
+		Closed,
+		ChannelClosed,
+		&ContextError{context.DeadlineExceeded},
+		&DeadlineError{time.Now()},
+		&SignalError{os.Interrupt},
+		&TimeoutError{time.Minute},
+	}
+	for _, cause := range causes {
+		if !errors.Is(fmt.Errorf("%w", cause), Interrupted) {
+			t.Errorf("fail error assertion for %q", cause)
+		}
+	}
+
+	if !errors.Is(&ContextError{context.DeadlineExceeded}, context.DeadlineExceeded) {
+		t.Error("fail context error assertion")
+	}
+	if errors.Unwrap(Interrupted) != nil {
+		t.Error("fail unwrap assertion")
+	}
+}
@@ -0,0 +1,126 @@
+// Package exec runs commands under breakers.
+package exec
+
+import (
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/kamilsk/breaker"
+)
+
+// Grace is the default time given to a command to exit
+// after the interruption signal before it's killed.
+const Grace = 10 * time.Second
+
+// Option configures the Run call.
+type Option func(*options)
+
+// WithSignal sets the signal sent to the command when the breaker is interrupted.
+// By default, it's the SIGTERM on Unix systems and the os.Interrupt on others.
+func WithSignal(sig os.Signal) Option {
+	return func(opts *options) {
+		opts.signal = sig
+	}
+}
+
+// WithGrace sets the time given to the command to exit
+// after the signal before it's killed.
+func WithGrace(grace time.Duration) Option {
+	return func(opts *options) {
+		opts.grace = grace
+	}
+}
+
+type options struct {
+	signal os.Signal
+	grace  time.Duration
+}
+
+// Run starts the command and waits for it to exit.
+// When the breaker is interrupted, it sends the signal to the process group
+// of the command, waits for the grace period and then kills the group.
+// It returns the error of the command if it exited by itself
+// and the *Error with the cause of interruption otherwise.
+// The command is not started if the breaker is already interrupted.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  cmd := exec.Command("server", "run", "--port=8080")
+//  if err := breakerexec.Run(shutdown, cmd, breakerexec.WithGrace(5*time.Second)); err != nil {
+//  	log.Println(err)
+//  }
+//
+func Run(br breaker.Interface, cmd *exec.Cmd, opts ...Option) error {
+	cfg := options{signal: interruption, grace: Grace}
+	for _, configure := range opts {
+		configure(&cfg)
+	}
+
+	if br.Err() != nil {
+		return &Error{Cause: breaker.Cause(br)}
+	}
+	prepare(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-br.Done():
+	}
+	// the command could exit at the same moment, then it's already reaped
+	// and its process group must not be signaled
+	select {
+	case err := <-exited:
+		return err
+	default:
+	}
+
+	grace := breaker.BreakByTimeout(cfg.grace)
+	defer grace.Close()
+	if err := signal(cmd.Process, cfg.signal); err != nil {
+		grace.Close()
+	}
+
+	var err error
+	killed := false
+	select {
+	case err = <-exited:
+	case <-grace.Done():
+		killed = kill(cmd.Process) == nil
+		err = <-exited
+	}
+	return &Error{Cause: breaker.Cause(br), Err: err, Killed: killed}
+}
+
+// Error is returned by the Run call when the command was interrupted by the breaker.
+type Error struct {
+	// Cause is the cause of the breaker interruption.
+	Cause error
+	// Err is the error of the command exit, nil if it wasn't started.
+	Err error
+	// Killed reports whether the command was killed after the grace period.
+	Killed bool
+}
+
+// Error returns the string representation of an error.
+func (err *Error) Error() string {
+	switch {
+	case err.Killed:
+		return "command killed: " + err.Cause.Error()
+	case err.Err == nil:
+		return "command interrupted: " + err.Cause.Error()
+	default:
+		return "command interrupted: " + err.Cause.Error() + ": " + err.Err.Error()
+	}
+}
+
+// Unwrap returns the cause of the breaker interruption.
+func (err *Error) Unwrap() error {
+	return err.Cause
+}
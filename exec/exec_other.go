@@ -0,0 +1,23 @@
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package exec
+
+import (
+	"os"
+	"os/exec"
+)
+
+var interruption = os.Interrupt
+
+// prepare does nothing, process groups are not supported.
+func prepare(*exec.Cmd) {}
+
+// signal sends the signal to the process.
+func signal(process *os.Process, sig os.Signal) error {
+	return process.Signal(sig)
+}
+
+// kill kills the process.
+func kill(process *os.Process) error {
+	return process.Kill()
+}
@@ -0,0 +1,138 @@
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+// +build go1.13
+
+package exec_test
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kamilsk/breaker"
+	. "github.com/kamilsk/breaker/exec"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("normal exit", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		defer br.Close()
+
+		if err := Run(br, exec.Command("true")); err != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+		var exit *exec.ExitError
+		if err := Run(br, exec.Command("false")); !errors.As(err, &exit) {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("graceful interruption", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		br := breaker.BreakByTimeout(timeout)
+		cmd := exec.Command("sh", "-c", "sleep 10 & wait")
+
+		start := time.Now()
+		err := Run(br, cmd, WithGrace(time.Hour))
+		if elapsed := time.Since(start); elapsed > timeout+5*delta {
+			t.Errorf("a command took %v", elapsed)
+		}
+
+		var stopped *Error
+		if !errors.As(err, &stopped) || stopped.Killed || !errors.Is(err, breaker.Interrupted) {
+			t.Fatalf("unexpected error %#v", err)
+		}
+		var exit *exec.ExitError
+		if !errors.As(stopped.Err, &exit) || exit.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+			t.Errorf("unexpected exit %#v", stopped.Err)
+		}
+	})
+
+	t.Run("session leader", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.BreakByTimeout(5 * delta)
+		cmd := exec.Command("sh", "-c", "sleep 10 & wait")
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+		var stopped *Error
+		if err := Run(br, cmd, WithGrace(time.Hour)); !errors.As(err, &stopped) || stopped.Killed {
+			t.Fatalf("unexpected error %#v", err)
+		}
+		var exit *exec.ExitError
+		if !errors.As(stopped.Err, &exit) || exit.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+			t.Errorf("unexpected exit %#v", stopped.Err)
+		}
+	})
+
+	t.Run("forced interruption", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		cmd := exec.Command("sh", "-c", "trap '' TERM; sleep 10")
+		time.AfterFunc(5*delta, br.Close)
+
+		start := time.Now()
+		err := Run(br, cmd, WithGrace(5*delta))
+		if elapsed := time.Since(start); elapsed > 10*delta+5*delta {
+			t.Errorf("a command took %v", elapsed)
+		}
+
+		var stopped *Error
+		if !errors.As(err, &stopped) || !stopped.Killed || breaker.Cause(br) != stopped.Cause {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("custom signal", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.BreakByTimeout(delta)
+		err := Run(br, exec.Command("sleep", "10"), WithSignal(syscall.SIGINT))
+
+		var exit *exec.ExitError
+		if !errors.As(err, new(*Error)) || !errors.As(err.(*Error).Err, &exit) ||
+			exit.Sys().(syscall.WaitStatus).Signal() != syscall.SIGINT {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("exit at interruption", func(t *testing.T) {
+		t.Parallel()
+
+		for i := range make([]struct{}, 50) {
+			br := breaker.New()
+			cmd := exec.Command("true")
+			time.AfterFunc(time.Duration(i)*50*time.Microsecond, br.Close)
+
+			var interrupted *Error
+			if err := Run(br, cmd, WithGrace(time.Hour)); errors.As(err, &interrupted) && interrupted.Killed {
+				t.Errorf("unexpected error %#v", err)
+			}
+		}
+	})
+
+	t.Run("already interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		br.Close()
+
+		cmd := exec.Command("true")
+		var stopped *Error
+		if err := Run(br, cmd); !errors.As(err, &stopped) || stopped.Err != nil || cmd.Process != nil {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+}
+
+// helpers
+
+const delta = 10 * time.Millisecond
@@ -0,0 +1,36 @@
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package exec
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+var interruption os.Signal = syscall.SIGTERM
+
+// prepare makes the command the leader of a new process group,
+// so the signals reach its children. A session leader already leads
+// its own process group, and it can't change the group.
+func prepare(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = new(syscall.SysProcAttr)
+	}
+	if !cmd.SysProcAttr.Setsid {
+		cmd.SysProcAttr.Setpgid = true
+	}
+}
+
+// signal sends the signal to the process group of the process.
+func signal(process *os.Process, sig os.Signal) error {
+	if sig, is := sig.(syscall.Signal); is {
+		return syscall.Kill(-process.Pid, sig)
+	}
+	return process.Signal(sig)
+}
+
+// kill kills the process group of the process.
+func kill(process *os.Process) error {
+	return syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,110 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// Group is a breaker combining a changing set of breakers.
+// It's interrupted when any of its members is interrupted.
+type Group interface {
+	Interface
+	// Add starts listening to the breakers.
+	Add(...Interface)
+	// Remove stops listening to the breakers.
+	Remove(...Interface)
+}
+
+// NewGroup returns a new breaker that closes the Done channel
+// when any of its current members is interrupted, like the Multiplex does,
+// but its members can be added and removed after creation.
+// The group doesn't close its members, so the caller remains responsible
+// for closing them. An empty group can be interrupted only by the Close call.
+// The cause of interruption is the cause of the interrupted member.
+//
+//  connections := breaker.NewGroup()
+//  defer connections.Close()
+//
+//  for conn := range listener.Accept() {
+//  	connections.Add(conn.Breaker())
+//  	go func() {
+//  		defer connections.Remove(conn.Breaker())
+//  		conn.Serve()
+//  	}()
+//  }
+//
+func NewGroup(breakers ...Interface) Group {
+	br := &groupBreaker{breaker: newBreaker(), members: make(map[Interface]func())}
+	br.afterFunc(br.detach)
+	br.Add(breakers...)
+	return br
+}
+
+type groupBreaker struct {
+	*breaker
+	guard   sync.Mutex
+	members map[Interface]func()
+}
+
+// Add starts listening to the breakers.
+// If any of them is already interrupted, the group is interrupted immediately.
+func (br *groupBreaker) Add(breakers ...Interface) {
+	for _, member := range breakers {
+		member := member
+		stop := afterFunc(member, func() { br.release(Cause(member)) })
+
+		br.guard.Lock()
+		_, present := br.members[member]
+		if br.members == nil || present {
+			br.guard.Unlock()
+			stop()
+			continue
+		}
+		br.members[member] = stop
+		br.guard.Unlock()
+	}
+}
+
+// Remove stops listening to the breakers. It doesn't close them.
+func (br *groupBreaker) Remove(breakers ...Interface) {
+	for _, member := range breakers {
+		br.guard.Lock()
+		stop := br.members[member]
+		delete(br.members, member)
+		br.guard.Unlock()
+
+		if stop != nil {
+			stop()
+		}
+	}
+}
+
+// Deadline returns the earliest deadline of the group members.
+func (br *groupBreaker) Deadline() (time.Time, bool) {
+	br.guard.Lock()
+	defer br.guard.Unlock()
+
+	var earliest time.Time
+	for br := range br.members {
+		if at, ok := deadline(br); ok && (earliest.IsZero() || at.Before(earliest)) {
+			earliest = at
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// detach stops listening to all members after the Done channel is closed.
+func (br *groupBreaker) detach() {
+	br.guard.Lock()
+	members := br.members
+	br.members = nil
+	br.guard.Unlock()
+
+	for _, stop := range members {
+		stop()
+	}
+}
+
+func (br *groupBreaker) trigger() Interface {
+	return br
+}
@@ -0,0 +1,124 @@
+package breaker_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestNewGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with breakers", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		member := BreakByTimeout(timeout)
+		br := NewGroup(BreakByTimeout(time.Hour), member)
+
+		start := time.Now()
+		<-br.Done()
+
+		checkDuration(t, start.Add(timeout), time.Now())
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != timeout {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("without breakers", func(t *testing.T) {
+		t.Parallel()
+
+		br := NewGroup()
+		checkBreakerIsNotReleased(t, br)
+
+		br.Close()
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("add breaker", func(t *testing.T) {
+		t.Parallel()
+
+		br := NewGroup()
+		member := New()
+		br.Add(member)
+		checkBreakerIsNotReleased(t, br)
+
+		cause := Error("connection lost")
+		CloseWithCause(member, cause)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != cause {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("add interrupted breaker", func(t *testing.T) {
+		t.Parallel()
+
+		member := New()
+		member.Close()
+
+		br := NewGroup()
+		br.Add(member)
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("remove breaker", func(t *testing.T) {
+		t.Parallel()
+
+		member := New()
+		br := NewGroup(member)
+		br.Remove(member)
+		checkBreakerIsNotReleased(t, member)
+
+		member.Close()
+		checkBreakerIsNotReleased(t, br)
+		br.Close()
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		member := BreakByTimeout(time.Hour)
+		br := NewGroup(member)
+		if at, ok := ToContext(br).Deadline(); !ok || at.IsZero() {
+			t.Error("a group lost the deadline of its member")
+		}
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleased(t, br)
+		checkBreakerIsNotReleased(t, member)
+		member.Close()
+	})
+
+	t.Run("concurrent membership", func(t *testing.T) {
+		t.Parallel()
+
+		br := NewGroup()
+		brs := make([]Interface, 0, times)
+		for range make([]struct{}, times) {
+			brs = append(brs, New())
+		}
+
+		wg := sync.WaitGroup{}
+		for _, member := range brs {
+			wg.Add(1)
+			go func(member Interface) {
+				defer wg.Done()
+				br.Add(member)
+				br.Remove(member)
+				br.Add(member)
+			}(member)
+		}
+		wg.Wait()
+		checkBreakerIsNotReleased(t, br)
+
+		brs[times-1].Close()
+		checkBreakerIsReleased(t, br)
+		for _, member := range brs {
+			member.Close()
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// +build go1.13
+
+// Package httpclient provides an http.RoundTripper
+// enforcing breakers on outgoing requests.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/kamilsk/breaker"
+)
+
+// Transport returns a new RoundTripper, which aborts requests
+// and reading of response bodies when the breaker is interrupted.
+// The breaker is shared by all requests, so the RoundTripper doesn't close it.
+// If the base RoundTripper is nil, the http.DefaultTransport is used.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  client := &http.Client{Transport: httpclient.Transport(nil, shutdown)}
+//
+func Transport(base http.RoundTripper, br breaker.Interface) http.RoundTripper {
+	return TransportFunc(base, func(*http.Request) breaker.Interface { return breaker.Observe(br) })
+}
+
+// TransportFunc returns a new RoundTripper, which creates a breaker
+// for each request by the factory and aborts the request and reading
+// of its response body when the breaker is interrupted.
+// The RoundTripper closes the breaker when the response body is closed.
+// The body of a 101 Switching Protocols response remains writable,
+// and the upgraded connection is closed when the breaker is interrupted.
+// If the base RoundTripper is nil, the http.DefaultTransport is used.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  client := &http.Client{Transport: httpclient.TransportFunc(nil, func(*http.Request) breaker.Interface {
+//  	return breaker.Observe(shutdown, breaker.BreakByTimeout(time.Second))
+//  })}
+//
+func TransportFunc(base http.RoundTripper, factory func(*http.Request) breaker.Interface) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base, factory}
+}
+
+type transport struct {
+	base    http.RoundTripper
+	factory func(*http.Request) breaker.Interface
+}
+
+// RoundTrip executes a single HTTP transaction under the breaker.
+// If the breaker is interrupted, it returns the *Error with its cause.
+func (rt *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	br := rt.factory(req)
+	ctx, cancel := context.WithCancel(req.Context())
+	interrupter := breaker.Child(br, breaker.BreakByContext(ctx, cancel))
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			interrupter.Close()
+			br.Close()
+		})
+	}
+
+	resp, err := rt.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		err = wrap(br, err)
+		release()
+		return nil, err
+	}
+	wrapped := &body{resp.Body, br, release}
+	if conn, is := resp.Body.(io.ReadWriteCloser); is && resp.StatusCode == http.StatusSwitchingProtocols {
+		// the upgraded connection is not bound to the Context of the request anymore
+		go func() {
+			<-interrupter.Done()
+			_ = conn.Close()
+		}()
+		resp.Body = &duplex{wrapped, conn}
+		return resp, nil
+	}
+	resp.Body = wrapped
+	return resp, nil
+}
+
+// CloseIdleConnections closes idle connections of the base RoundTripper
+// if it supports that.
+func (rt *transport) CloseIdleConnections() {
+	if base, is := rt.base.(interface{ CloseIdleConnections() }); is {
+		base.CloseIdleConnections()
+	}
+}
+
+type body struct {
+	io.ReadCloser
+	br      breaker.Interface
+	release func()
+}
+
+// Read reads the response body. If the breaker is interrupted,
+// it returns the *Error with its cause.
+func (body *body) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = wrap(body.br, err)
+	}
+	return n, err
+}
+
+// Close closes the response body and the breaker of the request.
+func (body *body) Close() error {
+	err := body.ReadCloser.Close()
+	body.release()
+	return err
+}
+
+// duplex is the body of the 101 Switching Protocols response,
+// which keeps the upgraded connection writable.
+type duplex struct {
+	*body
+	conn io.Writer
+}
+
+// Write writes the data to the upgraded connection. If the breaker is interrupted,
+// it returns the *Error with its cause.
+func (body *duplex) Write(p []byte) (int, error) {
+	n, err := body.conn.Write(p)
+	if err != nil {
+		err = wrap(body.br, err)
+	}
+	return n, err
+}
+
+// Error is returned by the RoundTripper when the request
+// or reading of its response body was aborted by the breaker.
+type Error struct {
+	// Cause is the cause of the breaker interruption.
+	Cause error
+	// Err is the error of the request or the response body.
+	Err error
+}
+
+// Error returns the string representation of an error.
+func (err *Error) Error() string {
+	return err.Err.Error() + ": " + err.Cause.Error()
+}
+
+// Is reports whether the error of the request matches the target.
+func (err *Error) Is(target error) bool {
+	return errors.Is(err.Err, target)
+}
+
+// Unwrap returns the cause of the breaker interruption.
+func (err *Error) Unwrap() error {
+	return err.Cause
+}
+
+func wrap(br breaker.Interface, err error) error {
+	if br.Err() == nil {
+		return err
+	}
+	return &Error{Cause: breaker.Cause(br), Err: err}
+}
@@ -0,0 +1,178 @@
+// +build go1.13
+
+package httpclient_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kamilsk/breaker"
+	. "github.com/kamilsk/breaker/httpclient"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request in time", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte("done"))
+		}))
+		defer server.Close()
+
+		shutdown := breaker.New()
+		defer shutdown.Close()
+
+		client := &http.Client{Transport: Transport(nil, shutdown)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil || string(body) != "done" {
+			t.Errorf("unexpected response %q with error %#v", body, err)
+		}
+		if shutdown.Err() != nil {
+			t.Error("a shared breaker is closed by the transport")
+		}
+	})
+
+	t.Run("breaker released", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer server.Close()
+
+		br := breaker.New()
+		client := &http.Client{Transport: TransportFunc(nil, func(*http.Request) breaker.Interface { return br })}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if br.Err() != nil {
+			t.Error("a request breaker is closed too early")
+		}
+
+		_ = resp.Body.Close()
+		<-br.Done()
+		if breaker.Cause(br) != breaker.Closed {
+			t.Errorf("unexpected cause %#v", breaker.Cause(br))
+		}
+	})
+
+	t.Run("request interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			<-unblock
+		}))
+		defer server.Close()
+		defer close(unblock)
+
+		timeout := 5 * delta
+		client := &http.Client{Transport: TransportFunc(nil, func(*http.Request) breaker.Interface {
+			return breaker.BreakByTimeout(timeout)
+		})}
+		start := time.Now()
+		_, err := client.Get(server.URL) //nolint:bodyclose
+
+		if elapsed := time.Since(start); elapsed > timeout+delta {
+			t.Errorf("a request took %v", elapsed)
+		}
+		var cause *breaker.TimeoutError
+		if !errors.Is(err, breaker.Interrupted) || !errors.As(err, &cause) || cause.Timeout != timeout {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("body reading interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte("partial"))
+			rw.(http.Flusher).Flush()
+			<-unblock
+		}))
+		defer server.Close()
+		defer close(unblock)
+
+		shutdown := breaker.New()
+		client := &http.Client{Transport: Transport(nil, shutdown)}
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		cause := breaker.Error("shutdown")
+		time.AfterFunc(delta, func() { breaker.CloseWithCause(shutdown, cause) })
+		if _, err := ioutil.ReadAll(resp.Body); !errors.Is(err, cause) {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+	t.Run("upgraded connection", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			conn, buf, err := rw.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("unexpected error %#v", err)
+				return
+			}
+			defer func() { _ = conn.Close() }()
+			_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+			_ = buf.Flush()
+			line, _ := buf.ReadString('\n')
+			_, _ = buf.WriteString(line)
+			_ = buf.Flush()
+			_, _ = buf.ReadString('\n')
+		}))
+		defer server.Close()
+
+		shutdown := breaker.New()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "echo")
+		client := &http.Client{Transport: Transport(nil, shutdown)}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		conn, is := resp.Body.(io.ReadWriteCloser)
+		if resp.StatusCode != http.StatusSwitchingProtocols || !is {
+			t.Fatalf("unexpected response %d %T", resp.StatusCode, resp.Body)
+		}
+		if _, err := conn.Write([]byte("ping\n")); err != nil {
+			t.Fatal(err)
+		}
+		if line, err := bufio.NewReader(conn).ReadString('\n'); err != nil || line != "ping\n" {
+			t.Errorf("unexpected echo %q with error %#v", line, err)
+		}
+
+		cause := breaker.Error("shutdown")
+		time.AfterFunc(delta, func() { breaker.CloseWithCause(shutdown, cause) })
+		if _, err := conn.Read(make([]byte, 1)); !errors.Is(err, cause) {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+}
+
+// helpers
+
+const delta = 10 * time.Millisecond
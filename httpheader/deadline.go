@@ -0,0 +1,144 @@
+// Package httpheader provides propagation of breaker deadlines
+// over HTTP headers.
+package httpheader
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kamilsk/breaker"
+)
+
+// Header is the default name of the header carrying the timeout.
+const Header = "X-Request-Timeout"
+
+// Margin is the default safety margin subtracted from the received timeout
+// to compensate the network latency and the clock skew.
+const Margin = 10 * time.Millisecond
+
+// InjectDeadline sets the time left before the breaker will be interrupted
+// to the default header. It does nothing if the breaker has no deadline.
+//
+//  interrupter := breaker.BreakByTimeout(time.Second)
+//  defer interrupter.Close()
+//
+//  req, err := http.NewRequestWithContext(breaker.ToContext(interrupter), http.MethodGet, url, nil)
+//  if err != nil { handle(err) }
+//  httpheader.InjectDeadline(req.Header, interrupter)
+//
+func InjectDeadline(h http.Header, br breaker.Interface) {
+	WithHeader(Header, Margin).InjectDeadline(h, br)
+}
+
+// BreakByHeader returns a new breaker, which closes the Done channel
+// when the timeout passed in the default header happens.
+// If the header is missing or invalid, the breaker has no deadline
+// and can be interrupted only by the Close call.
+//
+//  http.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+//  	interrupter := httpheader.BreakByHeader(req.Header)
+//  	defer interrupter.Close()
+//
+//  	background.Job().Do(interrupter)
+//  })
+//
+func BreakByHeader(h http.Header) breaker.Interface {
+	return WithHeader(Header, Margin).BreakByHeader(h)
+}
+
+// WithHeader returns the propagation of deadlines over the header with the name.
+// The margin is subtracted from the received timeout.
+func WithHeader(name string, margin time.Duration) Propagation {
+	return Propagation{name, margin}
+}
+
+// Propagation provides propagation of deadlines over the same header.
+type Propagation struct {
+	name   string
+	margin time.Duration
+}
+
+// InjectDeadline sets the time left before the breaker will be interrupted
+// to the header. It does nothing if the breaker has no deadline.
+func (p Propagation) InjectDeadline(h http.Header, br breaker.Interface) {
+	if left := breaker.Remaining(br); left != breaker.NoDeadline {
+		h.Set(p.name, FormatTimeout(left))
+	}
+}
+
+// BreakByHeader returns a new breaker, which closes the Done channel
+// when the timeout passed in the header happens.
+// If the header is missing or invalid, the breaker has no deadline
+// and can be interrupted only by the Close call.
+func (p Propagation) BreakByHeader(h http.Header) breaker.Interface {
+	timeout, err := ParseTimeout(h.Get(p.name))
+	if err != nil {
+		return breaker.New()
+	}
+	return breaker.BreakByDeadline(time.Now().Add(timeout - p.margin))
+}
+
+// ErrInvalidTimeout is returned by the ParseTimeout
+// if the value has an invalid format.
+var ErrInvalidTimeout = errors.New("httpheader: invalid timeout")
+
+// maxValue is the maximum value of the timeout, which has at most eight digits.
+const maxValue = 1e8 - 1
+
+var units = []struct {
+	unit byte
+	size time.Duration
+}{
+	{'n', time.Nanosecond},
+	{'u', time.Microsecond},
+	{'m', time.Millisecond},
+	{'S', time.Second},
+	{'M', time.Minute},
+	{'H', time.Hour},
+}
+
+// FormatTimeout returns the timeout in the format of the grpc-timeout header,
+// e.g. "100m" or "5S". It uses the finest unit fitting the eight digits
+// and rounds the timeout down to it. A negative timeout is formatted as zero.
+func FormatTimeout(timeout time.Duration) string {
+	if timeout < 0 {
+		timeout = 0
+	}
+	for _, unit := range units[:len(units)-1] {
+		if value := timeout / unit.size; value <= maxValue {
+			return strconv.FormatInt(int64(value), 10) + string(unit.unit)
+		}
+	}
+	return strconv.FormatInt(int64(timeout/time.Hour), 10) + "H"
+}
+
+// ParseTimeout parses the timeout in the format of the grpc-timeout header,
+// i.e. at most eight digits followed by one of the units:
+// H for hours, M for minutes, S for seconds, m for milliseconds,
+// u for microseconds and n for nanoseconds.
+func ParseTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, ErrInvalidTimeout
+	}
+	number, unit := value[:len(value)-1], value[len(value)-1]
+	for _, digit := range number {
+		if digit < '0' || digit > '9' {
+			return 0, ErrInvalidTimeout
+		}
+	}
+	amount, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidTimeout
+	}
+	for _, candidate := range units {
+		if candidate.unit == unit {
+			if max := int64(breaker.NoDeadline / candidate.size); amount > max {
+				return breaker.NoDeadline, nil
+			}
+			return time.Duration(amount) * candidate.size, nil
+		}
+	}
+	return 0, ErrInvalidTimeout
+}
@@ -0,0 +1,152 @@
+package httpheader_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kamilsk/breaker"
+	. "github.com/kamilsk/breaker/httpheader"
+)
+
+func TestInjectDeadline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with deadline", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.BreakByTimeout(time.Minute)
+		defer br.Close()
+
+		h := make(http.Header)
+		InjectDeadline(h, br)
+		timeout, err := ParseTimeout(h.Get(Header))
+		if err != nil || timeout > time.Minute || timeout < time.Minute-delta {
+			t.Errorf("unexpected timeout %v with error %#v", timeout, err)
+		}
+	})
+
+	t.Run("without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		defer br.Close()
+
+		h := make(http.Header)
+		InjectDeadline(h, br)
+		if _, present := h[Header]; present {
+			t.Error("a breaker without deadline is propagated")
+		}
+	})
+
+	t.Run("custom header", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		br.Close()
+
+		h := make(http.Header)
+		WithHeader("Grpc-Timeout", 0).InjectDeadline(h, br)
+		if value := h.Get("Grpc-Timeout"); value != "0n" {
+			t.Errorf("unexpected header value %q", value)
+		}
+	})
+}
+
+func TestBreakByHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with header", func(t *testing.T) {
+		t.Parallel()
+
+		h := make(http.Header)
+		h.Set(Header, "5S")
+		br := BreakByHeader(h)
+		defer br.Close()
+
+		at, ok := breaker.ToContext(br).Deadline()
+		if expected := time.Now().Add(5*time.Second - Margin); !ok || at.After(expected) || at.Before(expected.Add(-delta)) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+	})
+
+	t.Run("timeout happened", func(t *testing.T) {
+		t.Parallel()
+
+		h := make(http.Header)
+		h.Set("Grpc-Timeout", "20m")
+		br := WithHeader("Grpc-Timeout", 15*time.Millisecond).BreakByHeader(h)
+
+		start := time.Now()
+		<-br.Done()
+		if elapsed := time.Since(start); elapsed > 5*time.Millisecond+delta {
+			t.Errorf("a breaker took %v", elapsed)
+		}
+		if _, is := breaker.Cause(br).(*breaker.DeadlineError); !is {
+			t.Errorf("unexpected cause %#v", breaker.Cause(br))
+		}
+	})
+
+	t.Run("without header", func(t *testing.T) {
+		t.Parallel()
+
+		for _, value := range []string{"", "forever"} {
+			h := make(http.Header)
+			h.Set(Header, value)
+			br := BreakByHeader(h)
+			if _, ok := breaker.ToContext(br).Deadline(); ok || br.Err() != nil {
+				t.Errorf("unexpected breaker for %q", value)
+			}
+			br.Close()
+		}
+	})
+}
+
+func TestFormatTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[time.Duration]string{
+		-time.Second:                  "0n",
+		0:                             "0n",
+		99 * time.Millisecond:         "99000000n",
+		100 * time.Millisecond:        "100000u",
+		time.Minute + time.Nanosecond: "60000000u",
+		5 * time.Hour:                 "18000000m",
+		30 * time.Hour:                "108000S",
+		breaker.NoDeadline:            "2562047H",
+	}
+	for timeout, expected := range tests {
+		if actual := FormatTimeout(timeout); actual != expected {
+			t.Errorf("unexpected value %q for %v, expected %q", actual, timeout, expected)
+		}
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]time.Duration{
+		"1H":        time.Hour,
+		"2M":        2 * time.Minute,
+		"3S":        3 * time.Second,
+		"100m":      100 * time.Millisecond,
+		"5u":        5 * time.Microsecond,
+		"99999999n": 99999999,
+		"99999999H": breaker.NoDeadline,
+	}
+	for value, expected := range tests {
+		if actual, err := ParseTimeout(value); err != nil || actual != expected {
+			t.Errorf("unexpected timeout %v for %q with error %#v", actual, value, err)
+		}
+	}
+
+	for _, value := range []string{"", "S", "1", "1s", "-1S", "+1S", "123456789S", "1.5S"} {
+		if _, err := ParseTimeout(value); err != ErrInvalidTimeout {
+			t.Errorf("unexpected error %#v for %q", err, value)
+		}
+	}
+}
+
+// helpers
+
+const delta = 10 * time.Millisecond
@@ -0,0 +1,295 @@
+// +build go1.13
+
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kamilsk/breaker"
+)
+
+// MiddlewareOption configures the Middleware call.
+type MiddlewareOption func(*middlewareOptions)
+
+// WithBody sets the body of responses written by the Middleware
+// when the breaker is interrupted. By default, it's the status text.
+func WithBody(body string) MiddlewareOption {
+	return func(opts *middlewareOptions) {
+		opts.body = &body
+	}
+}
+
+type middlewareOptions struct {
+	body *string
+}
+
+// Middleware derives a per-request breaker from the Context of the request,
+// the parent breaker, e.g. the server shutdown, and the timeout.
+// The breaker is available to handlers by the FromRequest call,
+// and the Context of the request is canceled when it's interrupted.
+// If the breaker is interrupted before the handler writes a response,
+// the Middleware responds with 504 Gateway Timeout if the timeout happened
+// or 503 Service Unavailable otherwise, and the following writes
+// of the handler fail with the http.ErrHandlerTimeout.
+// The parent breaker is not closed by the Middleware.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  handler := httpserver.Middleware(time.Second, shutdown)(http.HandlerFunc(
+//  	func(rw http.ResponseWriter, req *http.Request) {
+//  		if err := retry.Retry(httpserver.FromRequest(req), action); err != nil {
+//  			return
+//  		}
+//  		_, _ = rw.Write([]byte("done"))
+//  	},
+//  ))
+//
+func Middleware(timeout time.Duration, parent breaker.Interface, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	var cfg middlewareOptions
+	for _, configure := range opts {
+		configure(&cfg)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			br := breaker.Child(parent, breaker.Multiplex(
+				breaker.BreakByContext(context.WithCancel(req.Context())),
+				breaker.BreakByTimeout(timeout),
+			))
+			defer br.Close()
+
+			// the handler observes the interruption only after the Middleware
+			// decided whether to respond instead of it
+			ctx, cancel := context.WithCancel(req.Context())
+			defer cancel()
+			interrupter := hold(br)
+			defer interrupter.Close()
+
+			req = req.WithContext(context.WithValue(ctx, key{}, interrupter))
+			w := &writer{ResponseWriter: rw, header: make(http.Header)}
+			done, panicked := make(chan struct{}), make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next.ServeHTTP(w, req)
+				w.finish()
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				return
+			case <-br.Done():
+			}
+
+			status := http.StatusServiceUnavailable
+			var timeout *breaker.TimeoutError
+			if errors.As(breaker.Cause(br), &timeout) {
+				status = http.StatusGatewayTimeout
+			}
+			body := http.StatusText(status)
+			if cfg.body != nil {
+				body = *cfg.body
+			}
+			interrupted := w.interrupt(status, body)
+			breaker.CloseWithCause(interrupter, breaker.Cause(br))
+			cancel()
+			if interrupted {
+				return
+			}
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+			}
+		})
+	}
+}
+
+// FromRequest returns the breaker attached to the request by the Middleware.
+// It has the deadline of the per-request breaker.
+// Without the Middleware, it returns a breaker based on the Context of the request.
+func FromRequest(req *http.Request) breaker.Interface {
+	if br, is := req.Context().Value(key{}).(breaker.Interface); is {
+		return br
+	}
+	ctx := req.Context()
+	return breaker.Custom(ctx.Done(), nil, ctx.Err)
+}
+
+type key struct{}
+
+// hold returns a new breaker with the same deadline as the passed one,
+// which is interrupted only by the Close call.
+func hold(br breaker.Interface) breaker.Interface {
+	left := breaker.Remaining(br)
+	if left == breaker.NoDeadline {
+		return breaker.New()
+	}
+	return breaker.WithClock(heldClock{}).BreakByTimeout(left)
+}
+
+// heldClock provides the current time, but its timers never fire.
+type heldClock struct{}
+
+func (heldClock) Now() time.Time {
+	return time.Now()
+}
+
+func (heldClock) NewTimer(time.Duration) breaker.Timer {
+	return heldTimer{}
+}
+
+func (heldClock) AfterFunc(time.Duration, func()) breaker.Timer {
+	return heldTimer{}
+}
+
+type heldTimer struct{}
+
+func (heldTimer) C() <-chan time.Time      { return nil }
+func (heldTimer) Stop() bool               { return true }
+func (heldTimer) Reset(time.Duration) bool { return true }
+
+// writer guards the response of the handler from writes
+// after the Middleware responded instead of it.
+type writer struct {
+	http.ResponseWriter
+	mu          sync.Mutex
+	header      http.Header
+	wrote       bool
+	interrupted bool
+}
+
+// Header returns the header map of the handler,
+// it's copied to the response on the first write.
+// After that, it returns the header map of the response,
+// so the handler can set trailers.
+func (w *writer) Header() http.Header {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wrote {
+		return w.ResponseWriter.Header()
+	}
+	return w.header
+}
+
+// Write writes the data as part of the handler response.
+func (w *writer) Write(data []byte) (int, error) {
+	if !w.claim() {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteHeader sends the response header with the status code.
+func (w *writer) WriteHeader(status int) {
+	if w.claim() {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+// Flush sends buffered data of the handler response to the client
+// if the response supports that.
+func (w *writer) Flush() {
+	if !w.claim() {
+		return
+	}
+	if flusher, is := w.ResponseWriter.(http.Flusher); is {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection
+// if the response supports that. The Middleware doesn't respond
+// instead of the handler after that.
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.interrupted {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	hijacker, is := w.ResponseWriter.(http.Hijacker)
+	if !is {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		w.wrote = true
+	}
+	return conn, rw, err
+}
+
+// ReadFrom writes the data from the reader as part of the handler response.
+func (w *writer) ReadFrom(src io.Reader) (int64, error) {
+	if !w.claim() {
+		return 0, http.ErrHandlerTimeout
+	}
+	if from, is := w.ResponseWriter.(io.ReaderFrom); is {
+		return from.ReadFrom(src)
+	}
+	return io.Copy(w.ResponseWriter, src)
+}
+
+// claim reserves the response for the handler unless the Middleware
+// has already responded instead of it. The writer is not locked
+// during the following writes, so a blocked write doesn't delay
+// the interruption of the handler.
+func (w *writer) claim() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.interrupted {
+		return false
+	}
+	w.commit()
+	return true
+}
+
+// interrupt writes the response instead of the handler
+// if the handler hasn't written anything yet.
+func (w *writer) interrupt(status int, body string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wrote {
+		return false
+	}
+	w.interrupted = true
+	w.ResponseWriter.WriteHeader(status)
+	_, _ = w.ResponseWriter.Write([]byte(body))
+	return true
+}
+
+// finish copies the header map of the handler to the response
+// if the handler hasn't written anything.
+func (w *writer) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.interrupted {
+		w.commit()
+	}
+}
+
+// commit copies the header map of the handler to the response once,
+// the writer must be locked.
+func (w *writer) commit() {
+	if w.wrote {
+		return
+	}
+	w.wrote = true
+	header := w.ResponseWriter.Header()
+	for name, values := range w.header {
+		header[name] = values
+	}
+}
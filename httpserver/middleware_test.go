@@ -0,0 +1,241 @@
+// +build go1.13
+
+package httpserver_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamilsk/breaker"
+	. "github.com/kamilsk/breaker/httpserver"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("handler in time", func(t *testing.T) {
+		t.Parallel()
+
+		shutdown := breaker.New()
+		defer shutdown.Close()
+
+		handler := Middleware(time.Hour, shutdown)(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if FromRequest(req).Err() != nil {
+				t.Error("a request breaker is interrupted too early")
+			}
+			if left := breaker.Remaining(FromRequest(req)); left > time.Hour || left < time.Hour-time.Minute {
+				t.Errorf("unexpected remaining time %v", left)
+			}
+			if _, ok := breaker.ToContext(FromRequest(req)).Deadline(); !ok {
+				t.Error("a request breaker has no deadline")
+			}
+			rw.Header().Set("X-Result", "done")
+			rw.WriteHeader(http.StatusAccepted)
+			_, _ = rw.Write([]byte("done"))
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Code != http.StatusAccepted || recorder.Body.String() != "done" || recorder.Header().Get("X-Result") != "done" {
+			t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+		}
+		if shutdown.Err() != nil {
+			t.Error("a parent breaker is closed by the middleware")
+		}
+	})
+
+	t.Run("timeout happened", func(t *testing.T) {
+		t.Parallel()
+
+		shutdown := breaker.New()
+		defer shutdown.Close()
+
+		failed := make(chan error, 1)
+		handler := Middleware(5*delta, shutdown, WithBody("try later"))(http.HandlerFunc(
+			func(rw http.ResponseWriter, req *http.Request) {
+				<-FromRequest(req).Done()
+				<-req.Context().Done()
+				_, err := rw.Write([]byte("done"))
+				failed <- err
+			},
+		))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Code != http.StatusGatewayTimeout || recorder.Body.String() != "try later" {
+			t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+		}
+		if err := <-failed; err != http.ErrHandlerTimeout {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("shutdown happened", func(t *testing.T) {
+		t.Parallel()
+
+		shutdown := breaker.New()
+		handler := Middleware(time.Hour, shutdown)(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			shutdown.Close()
+			<-FromRequest(req).Done()
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("handler already wrote", func(t *testing.T) {
+		t.Parallel()
+
+		shutdown := breaker.New()
+		defer shutdown.Close()
+
+		handler := Middleware(delta, shutdown)(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte("partial"))
+			<-FromRequest(req).Done()
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if recorder.Code != http.StatusOK || recorder.Body.String() != "partial" {
+			t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("streaming handler", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Middleware(time.Hour, breaker.New())(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			from, is := rw.(io.ReaderFrom)
+			if !is {
+				t.Error("a response doesn't support reading from")
+				return
+			}
+			_, _ = from.ReadFrom(strings.NewReader("event"))
+			rw.(http.Flusher).Flush()
+		}))
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		if !recorder.Flushed || recorder.Body.String() != "event" {
+			t.Errorf("unexpected response %v %q", recorder.Flushed, recorder.Body.String())
+		}
+	})
+
+	t.Run("handler with trailer", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(Middleware(time.Hour, breaker.New())(http.HandlerFunc(
+			func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Trailer", "X-Checksum")
+				_, _ = rw.Write([]byte("done"))
+				rw.Header().Set("X-Checksum", "sum")
+			},
+		)))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if body, _ := ioutil.ReadAll(resp.Body); string(body) != "done" || resp.Trailer.Get("X-Checksum") != "sum" {
+			t.Errorf("unexpected response %q with trailer %v", body, resp.Trailer)
+		}
+	})
+
+	t.Run("hijacking handler", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(Middleware(time.Hour, breaker.New())(http.HandlerFunc(
+			func(rw http.ResponseWriter, req *http.Request) {
+				conn, buf, err := rw.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Errorf("unexpected error %#v", err)
+					return
+				}
+				defer func() { _ = conn.Close() }()
+				_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+				_ = buf.Flush()
+			},
+		)))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if body, _ := ioutil.ReadAll(resp.Body); string(body) != "hijacked" {
+			t.Errorf("unexpected response %q", body)
+		}
+	})
+
+	t.Run("proxying handler", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Middleware(5*delta, breaker.New())(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = io.Copy(rw, stalled{req.Context()})
+		}))
+
+		served := make(chan struct{})
+		go func() {
+			defer close(served)
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+		select {
+		case <-served:
+		case <-time.After(time.Second):
+			t.Error("a handler is not interrupted")
+		}
+	})
+
+	t.Run("handler panicked", func(t *testing.T) {
+		t.Parallel()
+
+		handler := Middleware(time.Hour, breaker.New())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("unexpected")
+		}))
+
+		defer func() {
+			if recover() == nil {
+				t.Error("a panic is lost")
+			}
+		}()
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestFromRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	br := FromRequest(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	if br.Err() != nil {
+		t.Error("a request breaker is interrupted too early")
+	}
+
+	cancel()
+	<-br.Done()
+	if breaker.Cause(br) != context.Canceled {
+		t.Errorf("unexpected cause %#v", breaker.Cause(br))
+	}
+}
+
+// stalled is a reader blocked until the Context is done.
+type stalled struct {
+	ctx context.Context
+}
+
+func (r stalled) Read([]byte) (int, error) {
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
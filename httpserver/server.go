@@ -0,0 +1,136 @@
+// +build go1.13
+
+// Package httpserver provides a graceful runner of the http.Server
+// built on breakers.
+package httpserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/kamilsk/breaker"
+)
+
+// Option configures the Serve call.
+type Option func(*options)
+
+// WithDrain sets the breaker limiting the draining of in-flight requests.
+// When it's interrupted, the server closes all connections forcibly.
+// The breaker is not closed by the Serve.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  err := httpserver.Serve(shutdown, server, httpserver.WithDrain(breaker.Delay(shutdown, 10*time.Second)))
+//
+func WithDrain(br breaker.Interface) Option {
+	return func(opts *options) {
+		opts.drain = func() breaker.Interface { return breaker.Observe(br) }
+	}
+}
+
+// WithDrainTimeout limits the draining of in-flight requests by the timeout,
+// which starts when the server stops accepting new connections.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.drain = func() breaker.Interface { return breaker.BreakByTimeout(timeout) }
+	}
+}
+
+// WithListener makes the server accept connections on the listener
+// instead of the address of the server.
+func WithListener(listener net.Listener) Option {
+	return func(opts *options) {
+		opts.listener = listener
+	}
+}
+
+type options struct {
+	drain    func() breaker.Interface
+	listener net.Listener
+}
+
+// Serve starts the server and stops it when the breaker is interrupted.
+// The server stops accepting new connections and drains in-flight requests
+// until the drain breaker is interrupted, then it closes all connections forcibly.
+// Without the drain breaker, it waits for all requests to be done.
+// If the server has no BaseContext, the Context of requests is canceled
+// when the connections are closed forcibly.
+//
+// It returns the error of the server if it failed before the breaker
+// is interrupted and the *Error with the cause of interruption otherwise.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  server := &http.Server{Addr: ":8080", Handler: handler}
+//  if err := httpserver.Serve(shutdown, server, httpserver.WithDrainTimeout(10*time.Second)); err != nil {
+//  	log.Println(err)
+//  }
+//
+func Serve(br breaker.Interface, srv *http.Server, opts ...Option) error {
+	cfg := options{drain: breaker.New}
+	for _, configure := range opts {
+		configure(&cfg)
+	}
+
+	hard := breaker.New()
+	defer hard.Close()
+	if srv.BaseContext == nil {
+		srv.BaseContext = func(net.Listener) context.Context { return breaker.ToContext(hard) }
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		if cfg.listener != nil {
+			served <- srv.Serve(cfg.listener)
+			return
+		}
+		served <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-br.Done():
+	}
+
+	drain := cfg.drain()
+	defer drain.Close()
+
+	ctx := breaker.ToContext(drain)
+	err := srv.Shutdown(ctx)
+	forced := err != nil && ctx.Err() != nil
+	if forced {
+		breaker.CloseWithCause(hard, breaker.Cause(drain))
+	}
+	if err != nil {
+		_ = srv.Close()
+	}
+	<-served
+	if err != nil && !forced {
+		return err
+	}
+	return &Error{Cause: breaker.Cause(br), Forced: forced}
+}
+
+// Error is returned by the Serve call when the server was stopped by the breaker.
+type Error struct {
+	// Cause is the cause of the breaker interruption.
+	Cause error
+	// Forced reports whether connections were closed by the drain breaker.
+	Forced bool
+}
+
+// Error returns the string representation of an error.
+func (err *Error) Error() string {
+	if err.Forced {
+		return "server closed forcibly: " + err.Cause.Error()
+	}
+	return "server shut down: " + err.Cause.Error()
+}
+
+// Unwrap returns the cause of the breaker interruption.
+func (err *Error) Unwrap() error {
+	return err.Cause
+}
@@ -0,0 +1,160 @@
+// +build go1.13
+
+package httpserver_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kamilsk/breaker"
+	. "github.com/kamilsk/breaker/httpserver"
+)
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("graceful shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		started := make(chan struct{})
+		server := &http.Server{Handler: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			close(started)
+			time.Sleep(5 * delta)
+			_, _ = rw.Write([]byte("done"))
+		})}
+		listener := listen(t)
+
+		br := breaker.New()
+		served := serve(br, server, WithListener(listener), WithDrainTimeout(time.Hour))
+		response := request(t, listener)
+
+		<-started
+		cause := breaker.Error("shutdown")
+		breaker.CloseWithCause(br, cause)
+
+		if body := <-response; body != "done" {
+			t.Errorf("unexpected response %q", body)
+		}
+		var err *Error
+		if !errors.As(<-served, &err) || err.Forced || !errors.Is(err, cause) {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("forced shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		started, canceled := make(chan struct{}), make(chan error, 1)
+		server := &http.Server{Handler: http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			close(started)
+			<-req.Context().Done()
+			canceled <- req.Context().Err()
+		})}
+		listener := listen(t)
+
+		br := breaker.New()
+		served := serve(br, server, WithListener(listener), WithDrain(breaker.BreakByTimeout(5*delta)))
+		response := request(t, listener)
+
+		<-started
+		br.Close()
+
+		var err *Error
+		if !errors.As(<-served, &err) || !err.Forced || !errors.Is(err, breaker.Interrupted) {
+			t.Errorf("unexpected error %#v", err)
+		}
+		if err := <-canceled; err == nil {
+			t.Errorf("unexpected context error %#v", err)
+		}
+		<-response
+	})
+
+	t.Run("shared drain", func(t *testing.T) {
+		t.Parallel()
+
+		drain := breaker.BreakByTimeout(time.Hour)
+		defer drain.Close()
+
+		br := breaker.New()
+		served := serve(br, &http.Server{}, WithListener(listen(t)), WithDrain(drain))
+		br.Close()
+
+		var err *Error
+		if !errors.As(<-served, &err) || err.Forced {
+			t.Errorf("unexpected error %#v", err)
+		}
+		if drain.Err() != nil {
+			t.Error("a drain breaker is closed by the server")
+		}
+	})
+
+	t.Run("drain after shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		drain := breaker.New()
+		drain.Close()
+
+		br := breaker.New()
+		served := serve(br, &http.Server{}, WithListener(listen(t)), WithDrain(drain))
+		br.Close()
+
+		var err *Error
+		if !errors.As(<-served, &err) || err.Forced {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+
+	t.Run("server failure", func(t *testing.T) {
+		t.Parallel()
+
+		br := breaker.New()
+		defer br.Close()
+
+		server := &http.Server{Addr: "127.0.0.1:-1"}
+		var stopped *Error
+		if err := Serve(br, server); err == nil || errors.As(err, &stopped) {
+			t.Errorf("unexpected error %#v", err)
+		}
+	})
+}
+
+// helpers
+
+const delta = 10 * time.Millisecond
+
+func listen(tb testing.TB) net.Listener {
+	tb.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return listener
+}
+
+func serve(br breaker.Interface, srv *http.Server, opts ...Option) <-chan error {
+	served := make(chan error, 1)
+	go func() { served <- Serve(br, srv, opts...) }()
+	return served
+}
+
+func request(tb testing.TB, listener net.Listener) <-chan string {
+	tb.Helper()
+
+	response := make(chan string, 1)
+	go func() {
+		defer close(response)
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := ioutil.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+	return response
+}
@@ -0,0 +1,154 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// Resettable is a breaker whose interruption can be postponed
+// while an activity continues.
+type Resettable interface {
+	Interface
+	// Touch postpones the interruption by the idle timeout from now.
+	Touch()
+	// Extend postpones the interruption by the duration.
+	Extend(time.Duration)
+	// Reset replaces the idle timeout and postpones the interruption by it from now.
+	Reset(time.Duration)
+}
+
+// BreakByIdle closes the Done channel when no activity happens
+// during the idle timeout. The activity is reported by the Touch call.
+//
+//  interrupter := breaker.BreakByIdle(time.Minute)
+//  defer interrupter.Close()
+//
+//  for message := range websocket.Messages(interrupter) {
+//  	interrupter.Touch()
+//  	handle(message)
+//  }
+//
+func BreakByIdle(timeout time.Duration) Resettable {
+	return WithClock(systemClock).BreakByIdle(timeout)
+}
+
+func newIdleBreaker(clock Clock, timeout time.Duration) *idleBreaker {
+	deadline := clock.Now().Add(timeout)
+	return &idleBreaker{
+		breaker:   newClockBreaker(clock),
+		timeout:   timeout,
+		deadline:  deadline,
+		scheduled: deadline,
+	}
+}
+
+type idleBreaker struct {
+	*breaker
+	external  Timer
+	guard     sync.Mutex
+	stopped   bool
+	timeout   time.Duration
+	deadline  time.Time
+	scheduled time.Time
+}
+
+// Close closes the Done channel and releases resources associated with it.
+func (br *idleBreaker) Close() {
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *idleBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// Deadline returns the time when the Done channel will be closed
+// if no activity happens.
+func (br *idleBreaker) Deadline() (time.Time, bool) {
+	br.guard.Lock()
+	defer br.guard.Unlock()
+	return br.deadline, true
+}
+
+// Touch postpones the interruption by the idle timeout from now.
+func (br *idleBreaker) Touch() {
+	br.guard.Lock()
+	br.postpone(br.now().Add(br.timeout))
+	br.guard.Unlock()
+}
+
+// Extend postpones the interruption by the duration.
+func (br *idleBreaker) Extend(d time.Duration) {
+	br.guard.Lock()
+	br.postpone(br.deadline.Add(d))
+	br.guard.Unlock()
+}
+
+// Reset replaces the idle timeout and postpones the interruption by it from now.
+func (br *idleBreaker) Reset(timeout time.Duration) {
+	br.guard.Lock()
+	br.timeout = timeout
+	br.postpone(br.now().Add(timeout))
+	br.guard.Unlock()
+}
+
+// postpone moves the deadline and reschedules the timer
+// only if the deadline is earlier than it's scheduled.
+// It must be called under the guard.
+func (br *idleBreaker) postpone(deadline time.Time) {
+	if br.stopped {
+		return
+	}
+	br.deadline = deadline
+	if deadline.Before(br.scheduled) {
+		br.external.Reset(deadline.Sub(br.now()))
+		br.scheduled = deadline
+	}
+}
+
+// expire returns the cause of interruption if the deadline occurred
+// and reschedules the timer otherwise.
+func (br *idleBreaker) expire() error {
+	br.guard.Lock()
+	defer br.guard.Unlock()
+	if br.stopped {
+		return nil
+	}
+	if left := br.deadline.Sub(br.now()); left > 0 {
+		br.external.Reset(left)
+		br.scheduled = br.deadline
+		return nil
+	}
+	return &TimeoutError{br.timeout}
+}
+
+// fire is called by the timer to close the Done channel
+// if the deadline occurred.
+func (br *idleBreaker) fire() {
+	if cause := br.expire(); cause != nil {
+		br.release(cause)
+	}
+}
+
+// release stores the cause, stops the timer and closes the Done channel.
+func (br *idleBreaker) release(cause error) {
+	br.settle(func() {
+		br.reason = cause
+		br.guard.Lock()
+		br.stopped = true
+		if br.external != nil {
+			br.external.Stop()
+		}
+		br.guard.Unlock()
+	})
+}
+
+// trigger starts the timer to close the Done channel
+// when the deadline occurs.
+func (br *idleBreaker) trigger() Interface {
+	br.guard.Lock()
+	br.external = br.clock.AfterFunc(br.timeout, br.fire)
+	br.guard.Unlock()
+	return br
+}
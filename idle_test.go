@@ -0,0 +1,122 @@
+package breaker_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestBreakByIdle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without activity", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		br := BreakByIdle(timeout)
+
+		start := time.Now()
+		<-br.Done()
+
+		checkDuration(t, start.Add(timeout), time.Now())
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != timeout {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("with activity", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		br := BreakByIdle(timeout)
+
+		start := time.Now()
+		for range make([]struct{}, 5) {
+			time.Sleep(2 * delta)
+			br.Touch()
+		}
+		<-br.Done()
+
+		checkDuration(t, start.Add(15*delta), time.Now())
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("extend timeout", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		br := BreakByIdle(timeout)
+
+		start := time.Now()
+		br.Extend(5 * delta)
+		<-br.Done()
+
+		checkDuration(t, start.Add(10*delta), time.Now())
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("reset timeout", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByIdle(time.Hour)
+
+		start := time.Now()
+		br.Reset(5 * delta)
+		if left := Remaining(br); left > 5*delta {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+		<-br.Done()
+
+		checkDuration(t, start.Add(5*delta), time.Now())
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != 5*delta {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("reset concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByIdle(delta)
+
+		wg := new(sync.WaitGroup)
+		wg.Add(times)
+		for range make([]struct{}, times) {
+			go func() {
+				defer wg.Done()
+				for range make([]struct{}, times) {
+					br.Touch()
+					br.Extend(time.Millisecond)
+					br.Reset(delta)
+				}
+			}()
+		}
+		wg.Wait()
+
+		checkBreakerIsReleased(t, br)
+		br.Touch()
+	})
+
+	t.Run("timeout has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByIdle(-delta)
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByIdle(time.Hour)
+		checkBreakerIsNotReleased(t, br)
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != Closed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+}
@@ -26,5 +26,6 @@ type Interface interface {
 
 	// trigger is a private method to guarantee that the breakers come from
 	// this package and all of them return a valid Done channel.
+	// Third-party sources of cancellation can be adapted by the Custom.
 	trigger() Interface
 }
@@ -1,6 +1,11 @@
 package breaker
 
-import "testing"
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
 
 type extended interface {
 	Interface
@@ -16,10 +21,30 @@ var (
 	_ extended = new(signalBreaker)
 	_ extended = new(channelBreaker)
 	_ extended = new(contextBreaker)
+	_ extended = new(customBreaker)
+	_ extended = new(idleBreaker)
 	_ extended = new(timeoutBreaker)
 	_ extended = stub{}
 )
 
+var (
+	_ interface{ CloseWithCause(error) } = new(breaker)
+	_ interface{ CloseWithCause(error) } = new(signalBreaker)
+	_ interface{ CloseWithCause(error) } = new(channelBreaker)
+	_ interface{ CloseWithCause(error) } = new(contextBreaker)
+	_ interface{ CloseWithCause(error) } = new(customBreaker)
+	_ interface{ CloseWithCause(error) } = new(idleBreaker)
+	_ interface{ CloseWithCause(error) } = new(multiplexedBreaker)
+	_ interface{ CloseWithCause(error) } = new(timeoutBreaker)
+)
+
+var (
+	_ interface{ Deadline() (time.Time, bool) } = new(contextBreaker)
+	_ interface{ Deadline() (time.Time, bool) } = new(idleBreaker)
+	_ interface{ Deadline() (time.Time, bool) } = new(multiplexedBreaker)
+	_ interface{ Deadline() (time.Time, bool) } = new(timeoutBreaker)
+)
+
 func TestStub_internals(t *testing.T) {
 	var breaker stub
 
@@ -39,3 +64,151 @@ func TestStub_internals(t *testing.T) {
 		t.Error("unexpected behavior of stub's trigger method")
 	}
 }
+
+func TestChild_unbind(t *testing.T) {
+	parent := newBreaker()
+	child := Child(parent, New())
+	if hooks(parent) != 1 {
+		t.Error("a child is not bound to the parent")
+	}
+
+	child.Close()
+	if hooks(parent) != 0 {
+		t.Error("a closed child is still bound to the parent")
+	}
+}
+
+func TestHub_internals(t *testing.T) {
+	const total = 10
+
+	brs := make([]Interface, 0, total)
+	for range make([]struct{}, total) {
+		brs = append(brs, BreakBySignal(syscall.SIGUSR2, syscall.SIGUSR2))
+	}
+
+	notifier.mu.Lock()
+	relay := notifier.relays[syscall.SIGUSR2]
+	if relay == nil || len(relay.subscribers) != total {
+		t.Error("signal breakers are not subscribed")
+	}
+	notifier.mu.Unlock()
+
+	each(brs).Close()
+
+	notifier.mu.Lock()
+	if _, present := notifier.relays[syscall.SIGUSR2]; present {
+		t.Error("signal breakers are not unsubscribed")
+	}
+	notifier.mu.Unlock()
+}
+
+func TestMultiplex_internals(t *testing.T) {
+	t.Run("foreign breakers", func(t *testing.T) {
+		const total = 2*group + 1
+
+		brs := make([]Interface, 0, total)
+		for range make([]struct{}, total) {
+			brs = append(brs, foreign(make(chan struct{})))
+		}
+
+		br := All(brs...)
+		for _, br := range brs {
+			if br.Err() != nil {
+				t.Fatal("a foreign breaker is closed too early")
+			}
+			br.Close()
+		}
+		<-br.Done()
+		if which, idx := Which(br); which == nil || idx < 0 {
+			t.Error("a foreign breaker is not chosen")
+		}
+	})
+
+	t.Run("shared breaker", func(t *testing.T) {
+		shared := newBreaker()
+		br := Observe(shared, New())
+		if hooks(shared) != 1 {
+			t.Error("a multiplexer is not bound to the shared breaker")
+		}
+
+		br.Close()
+		<-br.Done()
+		if hooks(shared) != 0 {
+			t.Error("a closed multiplexer is still bound to the shared breaker")
+		}
+	})
+
+	t.Run("allocations", func(t *testing.T) {
+		measure := func(total int) float64 {
+			brs := make([]Interface, 0, total)
+			for range make([]struct{}, total) {
+				brs = append(brs, newBreaker())
+			}
+			return testing.AllocsPerRun(10, func() { Observe(brs...).Close() })
+		}
+		if few, many := measure(3), measure(100); few != many {
+			t.Errorf("allocations depend on the number of breakers: %v and %v", few, many)
+		}
+	})
+}
+
+func hooks(br *breaker) int {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	var total int
+	for h := br.hooks; h != nil; h = h.next {
+		total++
+	}
+	return total
+}
+
+type foreign chan struct{}
+
+func (br foreign) Close() {
+	select {
+	case <-br:
+	default:
+		close(br)
+	}
+}
+
+func (br foreign) Done() <-chan struct{} {
+	return br
+}
+
+func (br foreign) Err() error {
+	select {
+	case <-br:
+		return Interrupted
+	default:
+		return nil
+	}
+}
+
+func (br foreign) IsReleased() bool {
+	return br.Err() != nil
+}
+
+func (br foreign) trigger() Interface {
+	return br
+}
+
+func TestBreaker_goroutines(t *testing.T) {
+	const total = 100
+
+	brs := make([]Interface, 0, 4*total)
+	before := runtime.NumGoroutine()
+	for range make([]struct{}, total) {
+		brs = append(brs,
+			BreakByChannel(make(chan struct{})),
+			BreakByTimeout(time.Hour),
+			BreakByIdle(time.Hour),
+			Multiplex(New(), New()),
+		)
+	}
+	if after := runtime.NumGoroutine(); after-before >= total {
+		t.Errorf("breakers started %d goroutines", after-before)
+	}
+	each(brs).Close()
+}
@@ -1,6 +1,11 @@
 package breaker
 
-import "reflect"
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Multiplex combines multiple breakers into one.
 //
@@ -15,60 +20,345 @@ import "reflect"
 //
 func Multiplex(breakers ...Interface) Interface {
 	if len(breakers) == 0 {
-		return closedBreaker()
+		return closedBreaker(Interrupted)
 	}
-	for len(breakers) < 3 {
-		breakers = append(breakers, stub{})
+	return Quorum(1, breakers...)
+}
+
+// All combines multiple breakers into one, which is interrupted
+// when all of them are interrupted.
+//
+//  interrupter := breaker.All(shards...)
+//  defer interrupter.Close()
+//
+//  <-interrupter.Done() // wait until all shard workers are done
+//
+func All(breakers ...Interface) Interface {
+	return Quorum(len(breakers), breakers...)
+}
+
+// Observe combines multiple breakers into one like the Multiplex does,
+// but it doesn't close them when it's interrupted or closed.
+// It allows combining a shared breaker into many short-lived ones,
+// so the caller remains responsible for closing the observed breakers.
+//
+//  shutdown := breaker.BreakBySignal(os.Interrupt, syscall.SIGTERM)
+//  defer shutdown.Close()
+//
+//  http.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+//  	timeout := breaker.BreakByTimeout(time.Second)
+//  	defer timeout.Close()
+//
+//  	interrupter := breaker.Observe(shutdown, timeout)
+//  	defer interrupter.Close()
+//
+//  	background.Job().Do(interrupter)
+//  })
+//
+func Observe(breakers ...Interface) Interface {
+	if len(breakers) == 0 {
+		return closedBreaker(Interrupted)
 	}
-	return newMultiplexedBreaker(breakers).trigger()
+	br := newMultiplexedBreaker(breakers, 1)
+	br.shared = true
+	return br.trigger()
 }
 
-func newMultiplexedBreaker(breakers []Interface) *multiplexedBreaker {
-	return &multiplexedBreaker{newBreaker(), make(chan struct{}), breakers}
+// Quorum combines multiple breakers into one, which is interrupted
+// when at least n of them are interrupted. Like the Multiplex does,
+// it closes all of them when it's interrupted. If n exceeds the number
+// of breakers, it can be interrupted only by the Close call.
+//
+//  interrupter := breaker.Quorum(2,
+//  	health.BreakByFailure(primary),
+//  	health.BreakByFailure(secondary),
+//  	health.BreakByFailure(arbiter),
+//  )
+//  defer interrupter.Close()
+//
+//  <-interrupter.Done() // wait until a majority of sources report failure
+//
+func Quorum(n int, breakers ...Interface) Interface {
+	if len(breakers) == 0 || n < 1 {
+		each(breakers).CloseWithCause(Interrupted)
+		return closedBreaker(Interrupted)
+	}
+	return newMultiplexedBreaker(breakers, n).trigger()
+}
+
+// Which returns the multiplexed breaker that interrupted the passed one
+// and its position in the list passed to the Multiplex.
+// For the All and the Quorum, it returns the breaker that completed the quorum.
+// It returns nil and -1 if the breaker is not multiplexed, is not interrupted yet
+// or was closed explicitly.
+//
+//  interrupter := breaker.Multiplex(
+//  	breaker.BreakBySignal(os.Interrupt),
+//  	breaker.BreakByTimeout(time.Minute),
+//  )
+//  defer interrupter.Close()
+//
+//  <-interrupter.Done()
+//  if _, idx := breaker.Which(interrupter); idx == 0 {
+//  	log.Println("interrupted by signal")
+//  }
+//
+func Which(br Interface) (Interface, int) {
+	if br, is := br.(*multiplexedBreaker); is {
+		select {
+		case <-br.signal:
+			if br.chosen >= 0 {
+				return br.external[br.chosen], br.chosen
+			}
+		default:
+		}
+	}
+	return nil, -1
+}
+
+// group is the number of multiplexed breakers watched by one fixed-size select
+// if they can't notify the multiplexer by themselves.
+const group = 4
+
+func newMultiplexedBreaker(breakers []Interface, quorum int) *multiplexedBreaker {
+	return &multiplexedBreaker{breaker: newBreaker(), external: breakers, quorum: int32(quorum), chosen: -1}
 }
 
 type multiplexedBreaker struct {
 	*breaker
-	internal chan struct{}
 	external []Interface
+	ports    []port
+	quorum   int32
+	fired    int32
+	shared   bool
+	chosen   int
+	guard    sync.Mutex
+	stops    []func()
+	detached bool
+}
+
+// port is the hook registered in a multiplexed breaker of the package.
+// Ports are allocated at once for all multiplexed breakers,
+// so listening to them costs no allocation per breaker.
+type port struct {
+	hook
+	br    *multiplexedBreaker
+	index int
+	owner attacher
+}
+
+// attacher is implemented by the package breakers,
+// which accept hooks allocated by the caller.
+type attacher interface {
+	attach(*hook) bool
+	detach(*hook)
+}
+
+// call counts the multiplexed breaker as interrupted.
+func (p *port) call() {
+	p.br.fire(p.index)
 }
 
 // Close closes the Done channel and releases resources associated with it.
 func (br *multiplexedBreaker) Close() {
-	br.closer.Do(func() { close(br.internal) })
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *multiplexedBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// Deadline returns the earliest deadline at which the quorum
+// of multiplexed breakers is interrupted, i.e. the earliest one for the Multiplex
+// and the latest one for the All. Breakers already interrupted count
+// toward the quorum. There is no deadline if the rest of the quorum
+// can't be reached by deadlines of pending breakers.
+func (br *multiplexedBreaker) Deadline() (time.Time, bool) {
+	fired, deadlines := 0, make([]time.Time, 0, len(br.external))
+	for _, child := range br.external {
+		if child.Err() != nil {
+			fired++
+			continue
+		}
+		if at, ok := deadline(child); ok {
+			deadlines = append(deadlines, at)
+		}
+	}
+	needed := int(br.quorum) - fired
+	if needed <= 0 {
+		return br.now(), true
+	}
+	if len(deadlines) < needed {
+		return time.Time{}, false
+	}
+	sort.Slice(deadlines, func(i, j int) bool { return deadlines[i].Before(deadlines[j]) })
+	return deadlines[needed-1], true
+}
+
+// remaining returns the time left until the quorum of multiplexed breakers
+// is interrupted, counting breakers already interrupted.
+func (br *multiplexedBreaker) remaining() time.Duration {
+	fired, left := 0, make([]time.Duration, 0, len(br.external))
+	for _, child := range br.external {
+		if child.Err() != nil {
+			fired++
+			continue
+		}
+		if _, ok := deadline(child); ok {
+			left = append(left, Remaining(child))
+		}
+	}
+	needed := int(br.quorum) - fired
+	if needed <= 0 {
+		return 0
+	}
+	if len(left) < needed {
+		return NoDeadline
+	}
+	sort.Slice(left, func(i, j int) bool { return left[i] < left[j] })
+	return left[needed-1]
+}
+
+// release stores the cause and closes the Done channel.
+func (br *multiplexedBreaker) release(cause error) {
+	br.choose(-1, cause)
+}
+
+// choose stores the index of the interrupted breaker with its cause,
+// stops listening to multiplexed breakers, closes them if they are not shared
+// and closes the Done channel.
+func (br *multiplexedBreaker) choose(index int, cause error) {
+	chosen := false
+	br.closer.Do(func() {
+		br.chosen = index
+		br.reason = cause
+		chosen = true
+	})
+	if !chosen {
+		return
+	}
+
+	br.guard.Lock()
+	stops := br.stops
+	br.stops, br.detached = nil, true
+	br.guard.Unlock()
+	for i := range br.ports {
+		if p := &br.ports[i]; p.owner != nil {
+			p.owner.detach(&p.hook)
+		}
+	}
+	for _, stop := range stops {
+		stop()
+	}
+
+	if !br.shared {
+		each(br.external).CloseWithCause(br.reason)
+	}
+	br.broadcast()
+}
+
+// fire counts the interrupted breaker and chooses it
+// if it completes the quorum.
+func (br *multiplexedBreaker) fire(index int) {
+	if atomic.AddInt32(&br.fired, 1) == br.quorum {
+		br.choose(index, Cause(br.external[index]))
+	}
+}
+
+// bind keeps the function to stop listening to the multiplexed breaker
+// or calls it immediately if the multiplexer is already interrupted.
+func (br *multiplexedBreaker) bind(stop func()) {
+	br.guard.Lock()
+	if !br.detached {
+		br.stops = append(br.stops, stop)
+		stop = nil
+	}
+	br.guard.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// plug registers the port in the multiplexed breaker of the package
+// or counts it immediately if the breaker is already interrupted.
+func (br *multiplexedBreaker) plug(index int, owner attacher) {
+	p := &br.ports[index]
+	p.callback, p.br, p.index = p, br, index
+	if !owner.attach(&p.hook) {
+		br.fire(index)
+		return
+	}
+	br.guard.Lock()
+	detached := br.detached
+	if !detached {
+		p.owner = owner
+	}
+	br.guard.Unlock()
+	if detached {
+		owner.detach(&p.hook)
+	}
 }
 
 // trigger starts listening to the all Done channels of multiplexed breakers.
+// The package breakers notify the multiplexer by hooks preallocated in one slice
+// without any goroutine, others are watched by fixed-size selects,
+// one goroutine per group of them.
 func (br *multiplexedBreaker) trigger() Interface {
-	go func() {
-		if len(br.external) == 3 {
-			select {
-			case <-br.external[0].Done():
-			case <-br.external[1].Done():
-			case <-br.external[2].Done():
-			case <-br.internal:
-			}
-		} else {
-			brs := make([]reflect.SelectCase, 0, len(br.external)+1)
-			brs = append(brs, reflect.SelectCase{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(br.internal),
-			})
-			for _, br := range br.external {
-				brs = append(brs, reflect.SelectCase{
-					Dir:  reflect.SelectRecv,
-					Chan: reflect.ValueOf(br.Done()),
-				})
-			}
-			reflect.Select(brs)
+	br.ports = make([]port, len(br.external))
+	watched := make([]int, 0, group)
+	for i, child := range br.external {
+		index := i
+		if child, is := child.(attacher); is {
+			br.plug(index, child)
+			continue
 		}
-		each(br.external).Close()
-		br.Close()
-		close(br.signal)
-	}()
+		if child, is := child.(interface{ afterFunc(func()) func() }); is {
+			br.bind(child.afterFunc(func() { br.fire(index) }))
+			continue
+		}
+		if child.Done() == nil {
+			continue
+		}
+		if watched = append(watched, index); len(watched) == group {
+			go br.watch(watched)
+			watched = make([]int, 0, group)
+		}
+	}
+	if len(watched) > 0 {
+		go br.watch(watched)
+	}
 	return br
 }
 
+// watch waits until the group of multiplexed breakers is interrupted
+// and counts each of them. It returns if the Done channel is closed before.
+func (br *multiplexedBreaker) watch(indexes []int) {
+	var done [group]<-chan struct{}
+	for i, index := range indexes {
+		done[i] = br.external[index].Done()
+	}
+	for range indexes {
+		select {
+		case <-done[0]:
+			done[0] = nil
+			br.fire(indexes[0])
+		case <-done[1]:
+			done[1] = nil
+			br.fire(indexes[1])
+		case <-done[2]:
+			done[2] = nil
+			br.fire(indexes[2])
+		case <-done[3]:
+			done[3] = nil
+			br.fire(indexes[3])
+		case <-br.signal:
+			return
+		}
+	}
+}
+
 type each []Interface
 
 // Close closes all Done channels of a list of breakers
@@ -79,6 +369,14 @@ func (list each) Close() {
 	}
 }
 
+// CloseWithCause closes all Done channels of a list of breakers
+// with the cause and releases resources associated with them.
+func (list each) CloseWithCause(cause error) {
+	for _, br := range list {
+		CloseWithCause(br, cause)
+	}
+}
+
 type stub struct{}
 
 func (br stub) Close()                {}
@@ -2,7 +2,9 @@ package breaker_test
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -58,3 +60,322 @@ func TestMultiplex(t *testing.T) {
 		checkBreakerIsReleased(t, br)
 	})
 }
+
+func TestWhich(t *testing.T) {
+	t.Parallel()
+
+	t.Run("multiplexed breaker", func(t *testing.T) {
+		t.Parallel()
+
+		for _, total := range []int{1, 3, 5} {
+			brs := make([]Interface, 0, total)
+			for range make([]struct{}, total-1) {
+				brs = append(brs, BreakByTimeout(time.Hour))
+			}
+			fired := BreakByTimeout(delta)
+			brs = append(brs, fired)
+
+			br := Multiplex(brs...)
+			checkBreakerIsReleased(t, br)
+
+			if which, idx := Which(br); which != fired || idx != total-1 {
+				t.Errorf("unexpected breaker at position %d", idx)
+			}
+		}
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		br := Multiplex(BreakByTimeout(time.Hour))
+		if which, idx := Which(br); which != nil || idx != -1 {
+			t.Error("a breaker is not released yet")
+		}
+
+		br.Close()
+		checkBreakerIsReleased(t, br)
+		if which, idx := Which(br); which != nil || idx != -1 {
+			t.Errorf("unexpected breaker at position %d", idx)
+		}
+	})
+
+	t.Run("not multiplexed breaker", func(t *testing.T) {
+		t.Parallel()
+
+		br := BreakByTimeout(-delta)
+		if which, idx := Which(br); which != nil || idx != -1 {
+			t.Errorf("unexpected breaker at position %d", idx)
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with breakers", func(t *testing.T) {
+		t.Parallel()
+
+		for _, total := range []int{1, 3, 5} {
+			brs := make([]Interface, 0, total)
+			for i := 1; i <= total; i++ {
+				brs = append(brs, BreakByTimeout(time.Duration(i)*delta))
+			}
+
+			br := All(brs...)
+
+			start := time.Now()
+			<-br.Done()
+
+			checkDuration(t, start.Add(time.Duration(total)*delta), time.Now())
+			checkBreakerIsReleased(t, br)
+			if which, idx := Which(br); which != brs[total-1] || idx != total-1 {
+				t.Errorf("unexpected breaker at position %d", idx)
+			}
+		}
+	})
+
+	t.Run("without breakers", func(t *testing.T) {
+		t.Parallel()
+
+		br := All()
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		child := BreakByTimeout(time.Hour)
+		br := All(BreakByTimeout(-delta), child)
+		checkBreakerIsNotReleased(t, br)
+
+		br.Close()
+		checkBreakerIsReleased(t, br)
+		checkBreakerIsReleased(t, child)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		clock := WithClock(NewFakeClock(now))
+		br := All(clock.BreakByTimeout(time.Second), clock.BreakByTimeout(time.Hour))
+		defer br.Close()
+
+		if at, ok := ToContext(br).Deadline(); !ok || !at.Equal(now.Add(time.Hour)) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+		if left := Remaining(br); left != time.Hour {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		partial := All(clock.BreakByTimeout(time.Second), BreakBySignal(os.Kill))
+		defer partial.Close()
+
+		if at, ok := ToContext(partial).Deadline(); ok {
+			t.Errorf("unexpected deadline %v", at)
+		}
+		if left := Remaining(partial); left != NoDeadline {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		closed := New()
+		closed.Close()
+		rest := All(closed, clock.BreakByTimeout(time.Minute))
+		defer rest.Close()
+
+		if at, ok := ToContext(rest).Deadline(); !ok || !at.Equal(now.Add(time.Minute)) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+		if left := Remaining(rest); left != time.Minute {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+	})
+}
+
+func TestQuorum(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with breakers", func(t *testing.T) {
+		t.Parallel()
+
+		for _, total := range []int{3, 5} {
+			brs := make([]Interface, 0, total)
+			for i := 1; i <= total; i++ {
+				brs = append(brs, BreakByTimeout(time.Duration(i)*delta))
+			}
+			brs[total-1] = BreakByTimeout(time.Hour)
+
+			br := Quorum(2, brs...)
+
+			start := time.Now()
+			<-br.Done()
+
+			checkDuration(t, start.Add(2*delta), time.Now())
+			checkBreakerIsReleased(t, br)
+			checkBreakerIsReleased(t, brs[total-1])
+			if which, idx := Which(br); which != brs[1] || idx != 1 {
+				t.Errorf("unexpected breaker at position %d", idx)
+			}
+		}
+	})
+
+	t.Run("quorum is unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		br := Quorum(3, BreakByTimeout(-delta), BreakByTimeout(-delta))
+		time.Sleep(delta)
+		checkBreakerIsNotReleased(t, br)
+
+		br.Close()
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("empty quorum", func(t *testing.T) {
+		t.Parallel()
+
+		child := BreakByTimeout(time.Hour)
+		br := Quorum(0, child)
+		checkBreakerIsReleasedFast(t, br)
+		checkBreakerIsReleased(t, child)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		clock := WithClock(NewFakeClock(now))
+		br := Quorum(2, clock.BreakByTimeout(time.Hour), clock.BreakByTimeout(time.Second), clock.BreakByTimeout(time.Minute))
+		defer br.Close()
+
+		if at, ok := ToContext(br).Deadline(); !ok || !at.Equal(now.Add(time.Minute)) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+		if left := Remaining(br); left != time.Minute {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+
+		unreachable := Quorum(3, clock.BreakByTimeout(time.Second), clock.BreakByTimeout(time.Minute))
+		defer unreachable.Close()
+
+		if at, ok := ToContext(unreachable).Deadline(); ok {
+			t.Errorf("unexpected deadline %v", at)
+		}
+		if left := Remaining(unreachable); left != NoDeadline {
+			t.Errorf("unexpected remaining time %v", left)
+		}
+	})
+}
+
+func TestObserve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with breakers", func(t *testing.T) {
+		t.Parallel()
+
+		shared := BreakBySignal(os.Kill)
+		defer shared.Close()
+
+		for _, total := range []int{2, 3, 5} {
+			brs := []Interface{shared}
+			for range make([]struct{}, total-1) {
+				brs = append(brs, BreakByTimeout(delta))
+			}
+
+			br := Observe(brs...)
+			checkBreakerIsReleased(t, br)
+			checkBreakerIsNotReleased(t, shared)
+		}
+	})
+
+	t.Run("shared breaker", func(t *testing.T) {
+		t.Parallel()
+
+		shared := New()
+		first, second := Observe(shared, BreakByTimeout(time.Hour)), Observe(shared)
+
+		cause := Error("shutdown")
+		CloseWithCause(shared, cause)
+		checkBreakerIsReleased(t, first)
+		checkBreakerIsReleased(t, second)
+		if Cause(first) != cause || Cause(second) != cause {
+			t.Error("a shared breaker cause is lost")
+		}
+	})
+
+	t.Run("without breakers", func(t *testing.T) {
+		t.Parallel()
+
+		br := Observe()
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		child := BreakByTimeout(time.Hour)
+		br := Observe(child)
+		checkBreakerIsNotReleased(t, br)
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleased(t, br)
+		checkBreakerIsNotReleased(t, child)
+		child.Close()
+	})
+}
+
+func BenchmarkMultiplex(b *testing.B) {
+	for _, total := range []int{3, 10, 100, 1000} {
+		brs := make([]Interface, total)
+		b.Run(fmt.Sprintf("multiplex %d", total), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for i := range brs {
+					brs[i] = New()
+				}
+				b.StartTimer()
+				br := Multiplex(brs...)
+				brs[total-1].Close()
+				<-br.Done()
+			}
+		})
+		b.Run(fmt.Sprintf("reflect %d", total), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for i := range brs {
+					brs[i] = New()
+				}
+				b.StartTimer()
+				br := reflectMultiplex(brs...)
+				brs[total-1].Close()
+				<-br.Done()
+			}
+		})
+	}
+}
+
+// reflectMultiplex is a reference implementation of the Multiplex
+// based on the reflect.Select.
+func reflectMultiplex(breakers ...Interface) Interface {
+	br := New()
+	go func() {
+		brs := make([]reflect.SelectCase, 0, len(breakers)+1)
+		brs = append(brs, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(br.Done()),
+		})
+		for _, br := range breakers {
+			brs = append(brs, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(br.Done()),
+			})
+		}
+		reflect.Select(brs)
+		br.Close()
+		for _, br := range breakers {
+			br.Close()
+		}
+	}()
+	return br
+}
@@ -0,0 +1,206 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// wheel is the number of slots of the timer wheel.
+const wheel = 512
+
+// NewTimeoutPool returns a new pool of time-based breakers,
+// which share one ticking goroutine instead of a timer and a goroutine per breaker.
+// The breakers are interrupted at the first tick after their deadline,
+// so the resolution trades precision for throughput.
+// The goroutine is started on demand and exits when the pool has no breakers.
+//
+//  pool := breaker.NewTimeoutPool(10 * time.Millisecond)
+//
+//  http.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+//  	interrupter := pool.BreakByTimeout(100 * time.Millisecond)
+//  	defer interrupter.Close()
+//
+//  	upstream.Call(interrupter)
+//  })
+//
+func NewTimeoutPool(resolution time.Duration) *TimeoutPool {
+	return WithClock(systemClock).NewTimeoutPool(resolution)
+}
+
+// NewTimeoutPool returns a new pool of time-based breakers using the clock.
+func (c Clocked) NewTimeoutPool(resolution time.Duration) *TimeoutPool {
+	if resolution <= 0 {
+		resolution = time.Millisecond
+	}
+	return &TimeoutPool{clock: c.clock, resolution: resolution, origin: c.clock.Now()}
+}
+
+// TimeoutPool is a hashed timer wheel of time-based breakers.
+type TimeoutPool struct {
+	clock      Clock
+	resolution time.Duration
+	origin     time.Time
+	mu         sync.Mutex
+	slots      [wheel]*pooledBreaker
+	cursor     int64
+	size       int
+	running    bool
+}
+
+// BreakByDeadline closes the Done channel when the deadline occurs.
+func (pool *TimeoutPool) BreakByDeadline(deadline time.Time) Interface {
+	if deadline.Before(pool.clock.Now()) {
+		return closedBreaker(&DeadlineError{deadline})
+	}
+	return pool.schedule(deadline, &DeadlineError{deadline})
+}
+
+// BreakByTimeout closes the Done channel when the timeout happens.
+func (pool *TimeoutPool) BreakByTimeout(timeout time.Duration) Interface {
+	if timeout < 0 {
+		return closedBreaker(&TimeoutError{timeout})
+	}
+	return pool.schedule(pool.clock.Now().Add(timeout), &TimeoutError{timeout})
+}
+
+// schedule puts a new breaker into the slot of the first tick after the deadline
+// and starts the ticking goroutine if it's not running.
+func (pool *TimeoutPool) schedule(deadline time.Time, expired error) Interface {
+	br := &pooledBreaker{breaker: newClockBreaker(pool.clock), pool: pool, deadline: deadline, expired: expired}
+	tick := int64((deadline.Sub(pool.origin) + pool.resolution - 1) / pool.resolution)
+
+	pool.mu.Lock()
+	if !pool.running {
+		pool.running = true
+		pool.cursor = int64(pool.clock.Now().Sub(pool.origin) / pool.resolution)
+		go pool.run()
+	}
+	if tick <= pool.cursor {
+		tick = pool.cursor + 1
+	}
+	br.tick = tick
+	pool.link(br)
+	pool.mu.Unlock()
+	return br
+}
+
+// run advances the wheel every tick and interrupts expired breakers
+// until the pool has no breakers.
+func (pool *TimeoutPool) run() {
+	timer := pool.clock.NewTimer(pool.wait())
+	defer stop(timer)
+	for {
+		<-timer.C()
+		if !pool.advance() {
+			return
+		}
+		timer.Reset(pool.wait())
+	}
+}
+
+// wait returns the duration until the next tick.
+func (pool *TimeoutPool) wait() time.Duration {
+	pool.mu.Lock()
+	next := pool.origin.Add(time.Duration(pool.cursor+1) * pool.resolution)
+	pool.mu.Unlock()
+	return next.Sub(pool.clock.Now())
+}
+
+// advance processes all ticks passed up to now and reports
+// whether the pool still has breakers to wait for.
+func (pool *TimeoutPool) advance() bool {
+	var expired []*pooledBreaker
+	pool.mu.Lock()
+	current := int64(pool.clock.Now().Sub(pool.origin) / pool.resolution)
+	for ; pool.cursor < current && pool.size > 0; pool.cursor++ {
+		tick := pool.cursor + 1
+		for br := pool.slots[tick%wheel]; br != nil; {
+			next := br.next
+			if br.tick <= tick {
+				pool.unlink(br)
+				expired = append(expired, br)
+			}
+			br = next
+		}
+	}
+	pool.cursor = current
+	running := pool.size > 0
+	pool.running = running
+	pool.mu.Unlock()
+
+	for _, br := range expired {
+		br.release(br.expired)
+	}
+	return running
+}
+
+// link puts the breaker into its slot, the pool must be locked.
+func (pool *TimeoutPool) link(br *pooledBreaker) {
+	slot := &pool.slots[br.tick%wheel]
+	br.next = *slot
+	if br.next != nil {
+		br.next.prev = br
+	}
+	*slot = br
+	br.linked = true
+	pool.size++
+}
+
+// unlink removes the breaker from its slot if it's still there,
+// the pool must be locked.
+func (pool *TimeoutPool) unlink(br *pooledBreaker) {
+	if !br.linked {
+		return
+	}
+	if br.prev != nil {
+		br.prev.next = br.next
+	} else {
+		pool.slots[br.tick%wheel] = br.next
+	}
+	if br.next != nil {
+		br.next.prev = br.prev
+	}
+	br.prev, br.next, br.linked = nil, nil, false
+	pool.size--
+}
+
+type pooledBreaker struct {
+	*breaker
+	pool       *TimeoutPool
+	deadline   time.Time
+	expired    error
+	tick       int64
+	linked     bool
+	prev, next *pooledBreaker
+}
+
+// Deadline returns the time when the Done channel will be closed.
+func (br *pooledBreaker) Deadline() (time.Time, bool) {
+	return br.deadline, true
+}
+
+// Close closes the Done channel and releases resources associated with it.
+func (br *pooledBreaker) Close() {
+	br.release(Closed)
+}
+
+// CloseWithCause closes the Done channel and releases resources associated with it.
+// The cause will be returned by the Cause call, nil cause is replaced by Closed.
+func (br *pooledBreaker) CloseWithCause(cause error) {
+	br.release(closedBy(cause))
+}
+
+// release stores the cause, removes the breaker from the pool
+// and closes the Done channel.
+func (br *pooledBreaker) release(cause error) {
+	br.settle(func() {
+		br.reason = cause
+		br.pool.mu.Lock()
+		br.pool.unlink(br)
+		br.pool.mu.Unlock()
+	})
+}
+
+func (br *pooledBreaker) trigger() Interface {
+	return br
+}
@@ -0,0 +1,117 @@
+package breaker_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestNewTimeoutPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timeout happened", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := 5 * delta
+		br := NewTimeoutPool(time.Millisecond).BreakByTimeout(timeout)
+
+		start := time.Now()
+		<-br.Done()
+
+		checkDuration(t, start.Add(timeout), time.Now())
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*TimeoutError); !is || err.Timeout != timeout {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("timeout has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		br := NewTimeoutPool(time.Millisecond).BreakByTimeout(-time.Hour)
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("deadline occurred", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		pool := WithClock(clock).NewTimeoutPool(time.Second)
+		deadline := clock.Now().Add(time.Minute)
+		br := pool.BreakByDeadline(deadline)
+		if at, ok := ToContext(br).Deadline(); !ok || !at.Equal(deadline) {
+			t.Errorf("unexpected deadline %v", at)
+		}
+
+		clock.Advance(time.Minute)
+		checkBreakerIsReleased(t, br)
+		if err, is := Cause(br).(*DeadlineError); !is || !err.Deadline.Equal(deadline) {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+	})
+
+	t.Run("deadline has already passed", func(t *testing.T) {
+		t.Parallel()
+
+		br := NewTimeoutPool(time.Millisecond).BreakByDeadline(time.Now().Add(-time.Hour))
+		checkBreakerIsReleasedFast(t, br)
+	})
+
+	t.Run("more than one revolution", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Now())
+		pool := WithClock(clock).NewTimeoutPool(time.Second)
+		br := pool.BreakByTimeout(time.Hour)
+
+		for range make([]struct{}, 59) {
+			clock.Advance(time.Minute)
+			probe := pool.BreakByTimeout(0)
+			clock.Advance(time.Second)
+			<-probe.Done()
+			checkBreakerIsNotReleased(t, br)
+		}
+
+		clock.Advance(time.Minute)
+		checkBreakerIsReleased(t, br)
+	})
+
+	t.Run("close breaker", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewTimeoutPool(time.Millisecond)
+		br := pool.BreakByTimeout(time.Hour)
+		checkBreakerIsNotReleased(t, br)
+
+		closeBreakerConcurrently(br, times)
+		checkBreakerIsReleased(t, br)
+		if Cause(br) != Closed {
+			t.Errorf("unexpected cause %#v", Cause(br))
+		}
+
+		br = pool.BreakByTimeout(delta)
+		checkBreakerIsReleased(t, br)
+	})
+}
+
+func BenchmarkTimeoutPool(b *testing.B) {
+	pool := NewTimeoutPool(time.Millisecond)
+
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				pool.BreakByTimeout(time.Second).Close()
+			}
+		})
+	})
+	b.Run("timer", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				BreakByTimeout(time.Second).Close()
+			}
+		})
+	})
+}
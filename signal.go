@@ -0,0 +1,256 @@
+package breaker
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// notifier is the process-wide dispatcher of OS signals.
+// It registers once per signal and fans out to all live subscriptions.
+var notifier = &hub{relays: make(map[os.Signal]*relay)}
+
+type hub struct {
+	mu     sync.Mutex
+	relays map[os.Signal]*relay
+}
+
+type relay struct {
+	external    chan os.Signal
+	subscribers map[*subscription]struct{}
+}
+
+type subscription struct {
+	signals []os.Signal
+	notify  func(os.Signal)
+}
+
+// subscribe starts notifying the subscription about its signals.
+func (h *hub) subscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sig := range sub.signals {
+		r, present := h.relays[sig]
+		if !present {
+			r = &relay{make(chan os.Signal, 1), make(map[*subscription]struct{})}
+			h.relays[sig] = r
+			signal.Notify(r.external, sig)
+			go h.dispatch(r)
+		}
+		r.subscribers[sub] = struct{}{}
+	}
+}
+
+// unsubscribe stops notifying the subscription and stops listening
+// to the signals without subscribers.
+func (h *hub) unsubscribe(sub *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, sig := range sub.signals {
+		r, present := h.relays[sig]
+		if !present {
+			continue
+		}
+		delete(r.subscribers, sub)
+		if len(r.subscribers) == 0 {
+			delete(h.relays, sig)
+			signal.Stop(r.external)
+			close(r.external)
+		}
+	}
+}
+
+// dispatch fans out the received signals to the subscribers of the relay.
+func (h *hub) dispatch(r *relay) {
+	for sig := range r.external {
+		h.mu.Lock()
+		subscribers := make([]*subscription, 0, len(r.subscribers))
+		for sub := range r.subscribers {
+			subscribers = append(subscribers, sub)
+		}
+		h.mu.Unlock()
+		for _, sub := range subscribers {
+			sub.notify(sig)
+		}
+	}
+}
+
+// BreakBySignalEscalation returns two breakers for a two-phase shutdown.
+// The graceful one closes the Done channel when the first OS signal is received.
+// The force one closes the Done channel when the n-th signal is received
+// or the grace period passes after the graceful one is interrupted.
+// The zero grace period means no time limit. Closing the force breaker
+// closes the graceful one and stops listening to the signals.
+//
+//  graceful, force := breaker.BreakBySignalEscalation(2, 10*time.Second, os.Interrupt, syscall.SIGTERM)
+//  defer force.Close()
+//
+//  go func() {
+//  	<-force.Done()
+//  	os.Exit(1)
+//  }()
+//
+//  <-graceful.Done()
+//  server.Shutdown(breaker.ToContext(force))
+//
+func BreakBySignalEscalation(n int, grace time.Duration, sig ...os.Signal) (graceful, force Interface) {
+	if len(sig) == 0 {
+		return closedBreaker(Interrupted), closedBreaker(Interrupted)
+	}
+	br := newEscalationBreaker(n, grace, sig)
+	return br.graceful, br.trigger()
+}
+
+func newEscalationBreaker(n int, grace time.Duration, signals []os.Signal) *escalationBreaker {
+	if n < 1 {
+		n = 1
+	}
+	br := &escalationBreaker{
+		breaker:  newBreaker(),
+		graceful: newBreaker(),
+		external: make(chan os.Signal, n),
+		limit:    n,
+		grace:    grace,
+	}
+	br.subscription = &subscription{signals: signals, notify: func(sig os.Signal) {
+		select {
+		case br.external <- sig:
+		default:
+		}
+	}}
+	Child(br, br.graceful)
+	return br
+}
+
+type escalationBreaker struct {
+	*breaker
+	graceful     *breaker
+	external     chan os.Signal
+	subscription *subscription
+	limit        int
+	grace        time.Duration
+}
+
+// trigger starts listening to the required signals to close the Done channels
+// of the graceful and the force breakers.
+func (br *escalationBreaker) trigger() Interface {
+	notifier.subscribe(br.subscription)
+	go func() {
+		defer notifier.unsubscribe(br.subscription)
+
+		var (
+			graceful = br.graceful.Done()
+			deadline <-chan time.Time
+			received int
+			timer    Timer
+		)
+		defer func() {
+			if timer != nil {
+				stop(timer)
+			}
+		}()
+		for {
+			select {
+			case sig := <-br.external:
+				received++
+				br.graceful.release(&SignalError{sig})
+				if received >= br.limit {
+					br.release(&SignalError{sig})
+					return
+				}
+			case <-graceful:
+				graceful = nil
+				if br.grace > 0 {
+					timer = systemClock.NewTimer(br.grace)
+					deadline = timer.C()
+				}
+			case <-deadline:
+				br.release(&TimeoutError{br.grace})
+				return
+			case <-br.signal:
+				return
+			}
+		}
+	}()
+	return br
+}
+
+// NewSignalGenerations returns a source of breakers that re-arms after every
+// received OS signal. Each generation is a breaker that closes the Done channel
+// when the next signal is received, and the following one is armed at once,
+// so no signal is missed between generations.
+//
+//  reloads := breaker.NewSignalGenerations(syscall.SIGHUP)
+//  defer reloads.Close()
+//
+//  for {
+//  	interrupter := reloads.Current()
+//  	go background.Job().Do(interrupter)
+//
+//  	<-interrupter.Done()
+//  	if reloads.Closed() {
+//  		break
+//  	}
+//  	config.Reload()
+//  }
+//
+func NewSignalGenerations(sig ...os.Signal) *SignalGenerations {
+	gens := &SignalGenerations{current: newBreaker()}
+	gens.subscription = &subscription{signals: sig, notify: gens.rearm}
+	if len(sig) == 0 {
+		gens.Close()
+		return gens
+	}
+	notifier.subscribe(gens.subscription)
+	return gens
+}
+
+// SignalGenerations produces a fresh breaker per OS signal occurrence.
+type SignalGenerations struct {
+	mu           sync.Mutex
+	current      *breaker
+	closed       bool
+	subscription *subscription
+}
+
+// Close stops listening to the signals and closes the current generation.
+func (gens *SignalGenerations) Close() {
+	gens.mu.Lock()
+	current := gens.current
+	gens.closed = true
+	gens.mu.Unlock()
+	notifier.unsubscribe(gens.subscription)
+	current.Close()
+}
+
+// Closed returns true if the generations were closed by the Close call.
+func (gens *SignalGenerations) Closed() bool {
+	gens.mu.Lock()
+	defer gens.mu.Unlock()
+	return gens.closed
+}
+
+// Current returns the breaker of the current generation.
+// If the current generation was closed explicitly, a new one is armed.
+func (gens *SignalGenerations) Current() Interface {
+	gens.mu.Lock()
+	defer gens.mu.Unlock()
+	if !gens.closed && gens.current.Err() != nil {
+		gens.current = newBreaker()
+	}
+	return gens.current
+}
+
+// rearm arms the next generation and interrupts the previous one.
+func (gens *SignalGenerations) rearm(sig os.Signal) {
+	gens.mu.Lock()
+	if gens.closed {
+		gens.mu.Unlock()
+		return
+	}
+	previous := gens.current
+	gens.current = newBreaker()
+	gens.mu.Unlock()
+	previous.release(&SignalError{sig})
+}
@@ -0,0 +1,166 @@
+package breaker_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/kamilsk/breaker"
+)
+
+func TestBreakBySignalEscalation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with signals", func(t *testing.T) {
+		graceful, force := BreakBySignalEscalation(2, 0, syscall.SIGWINCH)
+		checkBreakerIsNotReleased(t, graceful)
+		checkBreakerIsNotReleased(t, force)
+
+		sendSignal(t, syscall.SIGWINCH)
+		checkBreakerIsReleased(t, graceful)
+		if err, is := Cause(graceful).(*SignalError); !is || err.Signal != syscall.SIGWINCH {
+			t.Errorf("unexpected cause %#v", Cause(graceful))
+		}
+
+		sendSignal(t, syscall.SIGWINCH)
+		checkBreakerIsReleased(t, force)
+		if err, is := Cause(force).(*SignalError); !is || err.Signal != syscall.SIGWINCH {
+			t.Errorf("unexpected cause %#v", Cause(force))
+		}
+	})
+
+	t.Run("grace period", func(t *testing.T) {
+		grace := 5 * delta
+		graceful, force := BreakBySignalEscalation(2, grace, syscall.SIGWINCH)
+
+		sendSignal(t, syscall.SIGWINCH)
+		checkBreakerIsReleased(t, graceful)
+
+		start := time.Now()
+		<-force.Done()
+
+		checkDuration(t, start.Add(grace), time.Now())
+		checkBreakerIsReleased(t, force)
+		if err, is := Cause(force).(*TimeoutError); !is || err.Timeout != grace {
+			t.Errorf("unexpected cause %#v", Cause(force))
+		}
+	})
+
+	t.Run("close graceful breaker", func(t *testing.T) {
+		t.Parallel()
+
+		grace := 5 * delta
+		graceful, force := BreakBySignalEscalation(2, grace, os.Kill)
+
+		graceful.Close()
+		checkBreakerIsReleased(t, graceful)
+		checkBreakerIsNotReleased(t, force)
+
+		start := time.Now()
+		<-force.Done()
+		checkDuration(t, start.Add(grace), time.Now())
+	})
+
+	t.Run("close force breaker", func(t *testing.T) {
+		t.Parallel()
+
+		graceful, force := BreakBySignalEscalation(2, time.Hour, os.Kill)
+
+		closeBreakerConcurrently(force, times)
+		checkBreakerIsReleased(t, force)
+		checkBreakerIsReleased(t, graceful)
+	})
+
+	t.Run("without signal", func(t *testing.T) {
+		t.Parallel()
+
+		graceful, force := BreakBySignalEscalation(2, time.Hour)
+		checkBreakerIsReleasedFast(t, graceful)
+		checkBreakerIsReleasedFast(t, force)
+	})
+}
+
+// helpers
+
+func sendSignal(tb testing.TB, sig os.Signal) {
+	tb.Helper()
+
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := proc.Signal(sig); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestNewSignalGenerations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with signals", func(t *testing.T) {
+		gens := NewSignalGenerations(syscall.SIGUSR1)
+		defer gens.Close()
+
+		first := gens.Current()
+		checkBreakerIsNotReleased(t, first)
+		if gens.Current() != first {
+			t.Error("a generation is changed without a signal")
+		}
+
+		sendSignal(t, syscall.SIGUSR1)
+		checkBreakerIsReleased(t, first)
+		if err, is := Cause(first).(*SignalError); !is || err.Signal != syscall.SIGUSR1 {
+			t.Errorf("unexpected cause %#v", Cause(first))
+		}
+
+		second := gens.Current()
+		if second == first {
+			t.Error("a generation is not re-armed")
+		}
+		checkBreakerIsNotReleased(t, second)
+
+		sendSignal(t, syscall.SIGUSR1)
+		checkBreakerIsReleased(t, second)
+	})
+
+	t.Run("close generation", func(t *testing.T) {
+		t.Parallel()
+
+		gens := NewSignalGenerations(os.Kill)
+		defer gens.Close()
+
+		first := gens.Current()
+		first.Close()
+		checkBreakerIsReleasedFast(t, first)
+
+		second := gens.Current()
+		if second == first {
+			t.Error("a generation is not re-armed")
+		}
+		checkBreakerIsNotReleased(t, second)
+	})
+
+	t.Run("close generations", func(t *testing.T) {
+		t.Parallel()
+
+		gens := NewSignalGenerations(os.Kill)
+		current := gens.Current()
+
+		gens.Close()
+		if !gens.Closed() {
+			t.Error("generations are not closed")
+		}
+		checkBreakerIsReleasedFast(t, current)
+		if gens.Current() != current {
+			t.Error("closed generations are re-armed")
+		}
+	})
+
+	t.Run("without signal", func(t *testing.T) {
+		t.Parallel()
+
+		gens := NewSignalGenerations()
+		checkBreakerIsReleasedFast(t, gens.Current())
+	})
+}